@@ -0,0 +1,71 @@
+package templater
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// collectComponentAssets collects the assets of the component file matched in the components directory,
+// if Config.BundleAssets is set. Registered components, having no file, have no assets.
+func (ec *executionContext) collectComponentAssets(componentDir, match string) error {
+	if !ec.cfg.BundleAssets || match == "" {
+		return nil
+	}
+	return ec.render.collectAssets(ec.cfg.FS, path.Join(componentDir, match), ec.cfg.FileExt)
+}
+
+// collectAssets reads the CSS and JS files alongside the component file, if not already collected,
+// eg card.css and card.js for card.html.tmpl.
+func (rs *renderState) collectAssets(fsys fs.FS, componentFile, fileExt string) error {
+	base := strings.TrimSuffix(componentFile, fileExt)
+
+	for _, asset := range []struct {
+		path string
+		dst  *[]string
+	}{
+		{base + ".css", &rs.styles},
+		{base + ".js", &rs.scripts},
+	} {
+		if rs.assets[asset.path] {
+			continue
+		}
+		rs.assets[asset.path] = true
+
+		b, err := fs.ReadFile(fsys, asset.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read component asset %s: %w", asset.path, err)
+		}
+
+		*asset.dst = append(*asset.dst, string(b))
+	}
+
+	return nil
+}
+
+// bundleAssets inlines the collected styles at the end of the page's <head>,
+// and the collected scripts at the end of its <body>.
+func (rs *renderState) bundleAssets(page []byte) []byte {
+	if len(rs.styles) > 0 {
+		page = insertBefore(page, "</head>", "<style>\n"+strings.Join(rs.styles, "\n")+"</style>\n")
+	}
+	if len(rs.scripts) > 0 {
+		page = insertBefore(page, "</body>", "<script>\n"+strings.Join(rs.scripts, "\n")+"</script>\n")
+	}
+	return page
+}
+
+// insertBefore inserts s before the last occurrence of the closing tag in page, or appends it if there is none.
+func insertBefore(page []byte, closingTag, s string) []byte {
+	i := bytes.LastIndex(bytes.ToLower(page), []byte(closingTag))
+	if i < 0 {
+		return append(page, s...)
+	}
+
+	return append(page[:i:i], append([]byte(s), page[i:]...)...)
+}
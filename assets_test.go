@@ -0,0 +1,36 @@
+package templater
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_BundleAssets(t *testing.T) {
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	}
+
+	b, err := new(Templater).With(cfg).ExecutePage("badges_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.NotContains(t, string(b), ".badge {", "expected no assets bundled unless enabled")
+
+	cfg.BundleAssets = true
+
+	b, err = new(Templater).With(cfg).ExecutePage("badges_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	page := string(b)
+	assert.Equal(t, 1, strings.Count(page, ".badge {"), "expected the stylesheet to be included once")
+	assert.Equal(t, 1, strings.Count(page, `console.log("badge")`), "expected the script to be included once")
+	assert.Contains(t, page, "<style>\n.badge { color: red; }\n</style>\n</head>", "expected the stylesheet at the end of the head")
+	assert.Contains(t, page, "<script>\nconsole.log(\"badge\");\n</script>\n</body>", "expected the script at the end of the body")
+	assert.Contains(t, page, `<div class="badge">one</div>`, "unexpected bytes returned")
+	assert.Contains(t, page, `<div class="badge">two</div>`, "unexpected bytes returned")
+}
@@ -0,0 +1,43 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ExecutePageBlock executes a single template defined within the page of the given name,
+// eg {{ define "sidebar" }}...{{ end }}, without the layout.
+// It's intended for partial updates of a known region of a page, eg by HTMX,
+// so its output is checked and encoded as that of the whole page would be, eg by Config.PostProcessors.
+// If the page defines no such template, an *ErrBlockNotDefined is returned.
+func (tm *Templater) ExecutePageBlock(name, block string, kvs ...any) ([]byte, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		return ec.executePageBlock(name, block, props)
+	}))
+}
+
+func (ec *executionContext) executePageBlock(name, block string, props map[string]any) ([]byte, error) {
+	layout, err := ec.parsePage(name, props)
+	if err != nil {
+		return nil, err
+	}
+
+	if t := layout.Lookup(block); t == nil || t.Tree == nil {
+		return nil, &ErrBlockNotDefined{
+			Page:  name,
+			Block: block,
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := layout.ExecuteTemplate(buf, block, props); err != nil {
+		return nil, fmt.Errorf("failed to execute block %s of page %s: %w", block, name, err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,51 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yosssi/gohtml"
+)
+
+func TestTemplater_ExecutePageBlock(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecutePageBlock("blocks_page", "sidebar", "X", "xyz")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<aside>
+  <div>
+    <div>
+      xyz
+    </div>
+    <div>
+      1
+    </div>
+    <div>
+      false
+    </div>
+  </div>
+</aside>`, gohtml.Format(string(b)), "unexpected bytes returned")
+
+	_, err = tm.ExecutePageBlock("blocks_page", "footer")
+	assert.Equal(t, &ErrBlockNotDefined{Page: "blocks_page", Block: "footer"}, err, "unexpected error returned")
+
+	t.Run("Given output encoding "+
+		"Then the block is encoded as the page would be", func(t *testing.T) {
+		tm := new(Templater).With(Config{
+			Dirs:           tm.cfg.Dirs,
+			OutputEncoding: "windows-1252",
+		})
+
+		b, err := tm.ExecutePageBlock("blocks_page", "sidebar", "X", "café")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Contains(t, string(b), "caf\xe9", "expected the block to be encoded")
+	})
+}
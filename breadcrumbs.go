@@ -0,0 +1,68 @@
+package templater
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/angelbeltran/templater/funcs"
+)
+
+// breadcrumbsLDPriority is the `headItem` priority of the script emitted by the `breadcrumbsLD` template function.
+const breadcrumbsLDPriority = 30
+
+type (
+	breadcrumbList struct {
+		Context         string               `json:"@context"`
+		Type            string               `json:"@type"`
+		ItemListElement []breadcrumbListItem `json:"itemListElement"`
+	}
+
+	breadcrumbListItem struct {
+		Type     string `json:"@type"`
+		Position int    `json:"position"`
+		Name     string `json:"name"`
+		Item     string `json:"item"`
+	}
+)
+
+// breadcrumbsLD is the implementation of the `breadcrumbsLD` template function.
+// It stores a JSON-LD BreadcrumbList script as a head item, for the `headItems` template function to emit,
+// listing each segment of the path of the page being executed, linked relative to baseURL, eg
+//
+//	{{ define "head" }}{{ breadcrumbsLD "https://example.com" }}{{ end }}
+//
+// for the page docs/getting_started lists docs, linking https://example.com/docs,
+// then getting_started, linking https://example.com/docs/getting_started.
+// As with `headItem`, the script is emitted once however many times it's stored.
+func (ec *executionContext) breadcrumbsLD(baseURL string) (string, error) {
+	if ec.page == "" {
+		return "", fmt.Errorf("breadcrumbsLD must be used within a page")
+	}
+
+	list := breadcrumbList{
+		Context: "https://schema.org",
+		Type:    "BreadcrumbList",
+	}
+
+	segments := getPathSegments(ec.page)
+	for i, segment := range segments {
+		item, err := funcs.URLJoin(baseURL, segments[:i+1]...)
+		if err != nil {
+			return "", fmt.Errorf("breadcrumbsLD: %w", err)
+		}
+
+		list.ItemListElement = append(list.ItemListElement, breadcrumbListItem{
+			Type:     "ListItem",
+			Position: i + 1,
+			Name:     segment,
+			Item:     item,
+		})
+	}
+
+	b, err := json.Marshal(list)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize breadcrumbs: %w", err)
+	}
+
+	return ec.render.headItem(breadcrumbsLDPriority, `<script type="application/ld+json">`+string(b)+`</script>`)
+}
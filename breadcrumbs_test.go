@@ -0,0 +1,61 @@
+package templater
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_BreadcrumbsLD(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecutePage("docs/getting_started")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	scripts := regexp.MustCompile(`<script type="application/ld\+json">(.*?)</script>`).FindAllSubmatch(b, -1)
+	require.Len(t, scripts, 1, "expected the breadcrumbs to be emitted once: %s", b)
+
+	var list map[string]any
+	require.NoError(t, json.Unmarshal(scripts[0][1], &list), "invalid JSON-LD emitted: %s", scripts[0][1])
+
+	assert.Equal(t, map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "BreadcrumbList",
+		"itemListElement": []any{
+			map[string]any{
+				"@type":    "ListItem",
+				"position": float64(1),
+				"name":     "docs",
+				"item":     "https://example.com/docs",
+			},
+			map[string]any{
+				"@type":    "ListItem",
+				"position": float64(2),
+				"name":     "getting_started",
+				"item":     "https://example.com/docs/getting_started",
+			},
+		},
+	}, list, "unexpected breadcrumbs emitted")
+}
+
+func TestTemplater_BreadcrumbsLD_NotInPage(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	_, err := tm.ExecuteInline(`{{ breadcrumbsLD "https://example.com" }}`)
+	assert.Error(t, err, "expected an error")
+}
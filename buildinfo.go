@@ -0,0 +1,43 @@
+package templater
+
+import (
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// BuildInfo describes the build of the application, as provided to templates by the `buildInfo` template function.
+type BuildInfo struct {
+	Version string
+	Commit  string
+	BuiltAt time.Time
+}
+
+// readBuildInfo reads the BuildInfo of the running binary, from its main module version and VCS stamping.
+var readBuildInfo = sync.OnceValue(func() BuildInfo {
+	var bi BuildInfo
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return bi
+	}
+
+	bi.Version = info.Main.Version
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			bi.Commit = s.Value
+		case "vcs.time":
+			bi.BuiltAt, _ = time.Parse(time.RFC3339, s.Value)
+		}
+	}
+
+	return bi
+})
+
+func (ec *executionContext) buildInfo() BuildInfo {
+	if ec.cfg.BuildInfo != nil {
+		return *ec.cfg.BuildInfo
+	}
+	return readBuildInfo()
+}
@@ -0,0 +1,85 @@
+package templater
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"text/template/parse"
+)
+
+// parsedFileKey identifies the parse trees of a template file cached when Config.CacheTemplates is set.
+type parsedFileKey struct {
+	// filename is the path of the template file, including Dirs.Base.
+	filename string
+	// name is the name of the file's template, eg "body" for page files.
+	name string
+}
+
+// parseFile parses the template file into t, the file's template being named name, as parseTemplateFile does.
+// With Config.CacheTemplates set, the file is read and parsed once, and copies of its parse trees are added to t instead.
+func (ec *executionContext) parseFile(t *template.Template, filename, name string) (*template.Template, error) {
+	if !ec.cfg.CacheTemplates {
+		return parseTemplateFile(t, ec.cfg.FS, filename, name)
+	}
+
+	key := parsedFileKey{filename: filename, name: name}
+
+	var trees map[string]*parse.Tree
+	if v, ok := ec.caches.parsed.Load(key); ok {
+		trees = v.(map[string]*parse.Tree)
+	} else {
+		b, err := fs.ReadFile(ec.cfg.FS, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		trees = make(map[string]*parse.Tree)
+
+		pt := parse.New(name)
+		// the functions are those of each execution, so are checked when it's executed instead
+		pt.Mode = parse.SkipFuncCheck
+		if _, err := pt.Parse(string(b), "", "", trees); err != nil {
+			return nil, err
+		}
+
+		ec.caches.parsed.Store(key, trees)
+	}
+
+	root := t
+	for treeName, tree := range trees {
+		// the trees are copied, as html/template rewrites those it executes to escape their output
+		added, err := t.AddParseTree(treeName, tree.Copy())
+		if err != nil {
+			return nil, fmt.Errorf("failed to add cached parse tree %s of %s: %w", treeName, filename, err)
+		}
+		if treeName == t.Name() {
+			// html/template adds the tree as a new template in place of t, rather than to t itself
+			root = added
+		}
+	}
+
+	return root, nil
+}
+
+// Invalidate discards the cached parse trees of the template file, if Config.CacheTemplates is set,
+// for it to be read and parsed again when next executed. name is the path of the file relative to Dirs.Base,
+// as given to Config.OnTemplateChange, eg components/card.html.tmpl.
+// Files changed are invalidated automatically when polling, via Config.PollInterval.
+func (tm *Templater) Invalidate(name string) {
+	filename := path.Join(tm.cfg.Dirs.Base, name)
+
+	tm.caches.parsed.Range(func(k, _ any) bool {
+		if k.(parsedFileKey).filename == filename {
+			tm.caches.parsed.Delete(k)
+		}
+		return true
+	})
+	tm.caches.dependencies.invalidate()
+}
+
+// InvalidateAll discards the cached parse trees of every template file, as Invalidate does.
+func (tm *Templater) InvalidateAll() {
+	tm.caches.parsed.Clear()
+	tm.caches.dependencies.invalidate()
+}
@@ -0,0 +1,68 @@
+package templater
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_CacheTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/layout.html.tmpl": {
+			Data: []byte(`<html><head>{{ block "head" . }}{{ end }}</head><body>{{ block "body" . }}{{ end }}</body></html>`),
+		},
+		"templates/pages/home.html.tmpl": {
+			Data: []byte(`{{ define "head" }}<title>{{ .Title }}</title>{{ end }}{{ component "card" "Title" .Title }}`),
+		},
+		"templates/components/card.html.tmpl": {
+			Data: []byte(`<div>{{ .Title }}</div>`),
+		},
+	}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "templates",
+			Pages:      "pages",
+			Components: "components",
+		},
+		FS:             fsys,
+		CacheTemplates: true,
+	})
+
+	execute := func(t *testing.T, title string) string {
+		b, err := tm.ExecutePage("home", "Title", title)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		return string(b)
+	}
+
+	t.Run("Given a page executed repeatedly "+
+		"Then each execution is rendered with its own props", func(t *testing.T) {
+		assert.Equal(t, `<html><head><title>A &lt; B</title></head><body><div>A &lt; B</div></body></html>`, execute(t, "A < B"), "unexpected output")
+		assert.Equal(t, `<html><head><title>C</title></head><body><div>C</div></body></html>`, execute(t, "C"), "unexpected output")
+	})
+
+	t.Run("Given a cached file changed "+
+		"Then the cached parse is used until invalidated", func(t *testing.T) {
+		fsys["templates/components/card.html.tmpl"] = &fstest.MapFile{Data: []byte(`<section>{{ .Title }}</section>`)}
+
+		assert.Equal(t, `<html><head><title>C</title></head><body><div>C</div></body></html>`, execute(t, "C"), "expected the cached parse to be used")
+
+		tm.Invalidate("components/card.html.tmpl")
+
+		assert.Equal(t, `<html><head><title>C</title></head><body><section>C</section></body></html>`, execute(t, "C"), "expected the file to be parsed again")
+	})
+
+	t.Run("Given every cached file invalidated "+
+		"Then every file is parsed again", func(t *testing.T) {
+		fsys["templates/layout.html.tmpl"] = &fstest.MapFile{Data: []byte(`<main>{{ block "body" . }}{{ end }}</main>`)}
+		fsys["templates/pages/home.html.tmpl"] = &fstest.MapFile{Data: []byte(`<p>{{ .Title }}</p>`)}
+
+		assert.Equal(t, `<html><head><title>C</title></head><body><section>C</section></body></html>`, execute(t, "C"), "expected the cached parses to be used")
+
+		tm.InvalidateAll()
+
+		assert.Equal(t, `<main><p>C</p></main>`, execute(t, "C"), "expected the files to be parsed again")
+	})
+}
@@ -0,0 +1,120 @@
+package templater
+
+import (
+	"encoding/json"
+	"html/template"
+	"reflect"
+	"time"
+
+	"github.com/angelbeltran/templater/funcs"
+)
+
+// impureFuncs are the template functions with side effects on the execution, eg storing values or head items,
+// or whose results depend on the request rather than the props, eg headers or the CSP nonce.
+// Components calling them, directly or via the components they use, aren't cached by `componentCached`.
+var impureFuncs = map[string]bool{
+	"store":         true,
+	"retrieve":      true,
+	"frame":         true,
+	"uniqueID":      true,
+	"refID":         true,
+	"headItem":      true,
+	"headItems":     true,
+	"header":        true,
+	"withConsent":   true,
+	"cspNonce":      true,
+	"experiment":    true,
+	"sortHeader":    true,
+	"isEmbedded":    true,
+	"breadcrumbsLD": true,
+	"ogImage":       true,
+	"hreflangs":     true,
+	"load":          true,
+}
+
+type cachedOutput struct {
+	b       []byte
+	expires time.Time
+}
+
+// executeComponentCached executes the component of the given name, unless it's been executed with identical props,
+// in which case the cached output is returned instead.
+// Props are compared by their JSON encoding, so components with props that can't be encoded, eg funcs,
+// are always executed.
+// Only pure components are cached, as a cached output replays none of the side effects of the execution.
+// Components calling any of impureFuncs are always executed, as are all components while
+// Config.BundleAssets, Config.CollectState, or Config.MaxComponentInstances is set, or while rendering
+// a tree or source map, all of which record each component executed.
+// Functions of Config.Funcs and Config.ContextFuncs are assumed to be pure.
+func (ec *executionContext) executeComponentCached(name string, props map[string]any) ([]byte, error) {
+	if !ec.cacheable() {
+		return ec.executeComponent(name, props)
+	}
+
+	encoded, err := json.Marshal(props)
+	if err != nil {
+		return ec.executeComponent(name, props)
+	}
+	key := name + "\x00" + string(encoded)
+
+	if ec.cfg.ComponentCacheTTL <= 0 {
+		if b, ok := ec.render.outputs[key]; ok {
+			return b, nil
+		}
+
+		b, pure, err := ec.executeComponentPure(name, props)
+		if err != nil || !pure {
+			return b, err
+		}
+		ec.render.outputs[key] = b
+
+		return b, nil
+	}
+
+	now := time.Now()
+	if v, ok := ec.caches.outputs.Load(key); ok {
+		if cached := v.(cachedOutput); now.Before(cached.expires) {
+			return cached.b, nil
+		}
+	}
+
+	b, pure, err := ec.executeComponentPure(name, props)
+	if err != nil || !pure {
+		return b, err
+	}
+	ec.caches.outputs.Store(key, cachedOutput{
+		b:       b,
+		expires: now.Add(ec.cfg.ComponentCacheTTL),
+	})
+
+	return b, nil
+}
+
+// executeComponentPure executes the component of the given name, reporting whether it's pure,
+// neither it nor any component it uses having called any of impureFuncs.
+func (ec *executionContext) executeComponentPure(name string, props map[string]any) (b []byte, pure bool, err error) {
+	before := ec.render.impureCalls
+	b, err = ec.executeComponent(name, props)
+	return b, ec.render.impureCalls == before, err
+}
+
+// cacheable reports whether component outputs may be cached in the execution,
+// no feature recording each component executed being in use.
+func (ec *executionContext) cacheable() bool {
+	return !ec.cfg.BundleAssets && !ec.cfg.CollectState && ec.cfg.MaxComponentInstances <= 0 &&
+		ec.node == nil && ec.render.sources == nil
+}
+
+// trackImpureCalls wraps each of impureFuncs in m to count its calls, for executeComponentPure.
+func (ec *executionContext) trackImpureCalls(m template.FuncMap) template.FuncMap {
+	return funcs.Wrap(m, func(name string, fn any) any {
+		if !impureFuncs[name] {
+			return fn
+		}
+
+		return funcs.Decorate(fn, func(args []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value {
+			ec.render.impureCalls++
+			return call(args)
+		})
+	})
+}
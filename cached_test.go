@@ -0,0 +1,137 @@
+package templater
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ComponentCached(t *testing.T) {
+	type (
+		Expected struct {
+			FirstRenders  int
+			SecondRenders int
+		}
+		Test struct {
+			Name     string
+			TTL      time.Duration
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given no ttl " +
+				"Then components are cached within an execution",
+			Expected: Expected{
+				FirstRenders:  2,
+				SecondRenders: 2,
+			},
+		},
+		{
+			Name: "Given a ttl " +
+				"Then components are cached across executions",
+			TTL: time.Minute,
+			Expected: Expected{
+				FirstRenders:  2,
+				SecondRenders: 0,
+			},
+		},
+	}
+
+	const body = `{{ componentCached "component_1" "X" "a" }}{{ componentCached "component_1" "X" "a" }}` +
+		`{{ componentCached "component_1" "X" "b" }}{{ componentCached "component_1" "X" "a" }}`
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var renders int
+
+			tm := new(Templater).With(Config{
+				Dirs: DirsConfig{
+					Base:       "test_dir/test_templates",
+					Pages:      "test_pages",
+					Components: "test_components",
+				},
+				ComponentCacheTTL: test.TTL,
+				BeforeRender: func(kind, name string, props map[string]any) {
+					renders++
+				},
+			})
+
+			b, err := tm.ExecuteInline(body)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+			assert.Equal(t, test.Expected.FirstRenders, renders, "unexpected number of renders")
+
+			expected, err := tm.ExecuteInline(`{{ component "component_1" "X" "a" }}{{ component "component_1" "X" "a" }}` +
+				`{{ component "component_1" "X" "b" }}{{ component "component_1" "X" "a" }}`)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+			assert.Equal(t, string(expected), string(b), "expected the cached output to match the uncached output")
+
+			renders = 0
+			b, err = tm.ExecuteInline(body)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+			assert.Equal(t, test.Expected.SecondRenders, renders, "unexpected number of renders")
+			assert.Equal(t, string(expected), string(b), "expected the cached output to match the uncached output")
+		})
+	}
+}
+
+func TestTemplater_ComponentCachedImpure(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		ComponentCacheTTL: time.Minute,
+	})
+
+	t.Run("Given a component depending on the request "+
+		"Then its output isn't shared across executions", func(t *testing.T) {
+		b, err := tm.WithNonce("first").ExecuteInline(`{{ componentCached "nonced_script" }}`)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, "<script nonce=\"first\">init()</script>\n", string(b), "unexpected output")
+
+		b, err = tm.WithNonce("second").ExecuteInline(`{{ componentCached "nonced_script" }}`)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, "<script nonce=\"second\">init()</script>\n", string(b), "unexpected output")
+	})
+
+	t.Run("Given a component with side effects "+
+		"Then it's executed each time", func(t *testing.T) {
+		var renders int
+
+		tm := new(Templater).With(Config{
+			Dirs:              tm.cfg.Dirs,
+			ComponentCacheTTL: time.Minute,
+			BeforeRender: func(kind, name string, props map[string]any) {
+				renders++
+			},
+		})
+
+		b, err := tm.ExecuteInline(`{{ componentCached "heading" "Title" "A" }}{{ componentCached "heading" "Title" "A" }}{{ len (retrieve "toc") }}`)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, "<h2>A</h2>\n<h2>A</h2>\n2", string(b), "unexpected output")
+		assert.Equal(t, 2, renders, "unexpected number of renders")
+	})
+
+	t.Run("Given components recorded by the execution "+
+		"Then they're executed each time", func(t *testing.T) {
+		var renders int
+
+		tm := new(Templater).With(Config{
+			Dirs:              tm.cfg.Dirs,
+			ComponentCacheTTL: time.Minute,
+			CollectState:      true,
+			BeforeRender: func(kind, name string, props map[string]any) {
+				renders++
+			},
+		})
+
+		_, err := tm.ExecuteInline(`{{ componentCached "component_1" "X" "a" }}{{ componentCached "component_1" "X" "a" }}`)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, 2, renders, "unexpected number of renders")
+	})
+}
@@ -0,0 +1,88 @@
+package templater
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTemplater_Concurrency executes pages from many goroutines with a single Templater,
+// to be run with the race detector, eg go test -race.
+func TestTemplater_Concurrency(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		CacheTemplates:    true,
+		ComponentCacheTTL: time.Minute,
+		BundleAssets:      true,
+		TwoPass:           true,
+	})
+	require.NoError(t, tm.Override(RenderKindPage, "concurrent_page", `
+		{{ define "head" }}{{ headItem 30 "<script src=\"/app.js\"></script>" }}{{ end }}
+		{{ store "notes" .N }}
+		<p>{{ .N }}</p>
+		{{ componentCached "badge" "Label" "cached" }}
+		{{ component "badge" "Label" .N }}
+		{{ svg "star" }}
+		{{ lqip "sample.png" }}
+		<ul>{{ range retrieve "notes" }}<li>{{ . }}</li>{{ end }}</ul>
+	`))
+
+	expected := make(map[string][]byte)
+	for _, name := range []string{"nested_page", "badges_page", "styled_page", "concurrent_page"} {
+		b, err := tm.ExecutePage(name, "N", 0)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		expected[name] = b
+	}
+
+	const goroutines = 8
+	const iterations = 5
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*iterations)
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range iterations {
+				for name, want := range expected {
+					// the concurrent page is executed with its own props, so its output differs
+					n := 0
+					if name == "concurrent_page" {
+						n = g*iterations + i
+					}
+
+					b, err := tm.ExecutePage(name, "N", n)
+					if err != nil {
+						errs <- err
+						continue
+					}
+					if name == "concurrent_page" {
+						if !assert.Contains(t, string(b), fmt.Sprintf("<p>%d</p>", n), "unexpected output") {
+							return
+						}
+						continue
+					}
+					if !assert.Equal(t, string(want), string(b), "unexpected output of %s", name) {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err, "unexpected error returned: %+v", err)
+	}
+}
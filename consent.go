@@ -0,0 +1,20 @@
+package templater
+
+import "slices"
+
+// WithConsent returns a copy of the Templater which reports the given consent categories as granted,
+// eg "analytics", to the `withConsent` template function of every execution.
+// Any categories granted by the Templater are replaced.
+func (tm *Templater) WithConsent(categories ...string) *Templater {
+	cpy := *tm
+	cpy.consent = categories
+	return &cpy
+}
+
+// withConsent is the implementation of the `withConsent` template function.
+// It reports whether the consent category was granted via WithConsent, eg to gate analytics scripts:
+//
+//	{{ if withConsent "analytics" }}<script src="/analytics.js"></script>{{ end }}
+func (ec *executionContext) withConsent(category string) bool {
+	return slices.Contains(ec.consent, category)
+}
@@ -0,0 +1,33 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_WithConsent(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	const body = `{{ if withConsent "analytics" }}<script src="/analytics.js"></script>{{ end }}` +
+		`{{ if withConsent "marketing" }}<script src="/ads.js"></script>{{ end }}`
+
+	b, err := tm.ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Empty(t, string(b), "expected no scripts without consent")
+
+	b, err = tm.WithConsent("analytics").ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<script src="/analytics.js"></script>`, string(b), "expected only the consented script")
+
+	b, err = tm.WithConsent("analytics").ExecuteInline(`{{ component "consented" }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<p>analytics</p>`+"\n", string(b), "expected consent to be provided to components")
+}
@@ -0,0 +1,100 @@
+package templater
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"reflect"
+
+	"github.com/angelbeltran/templater/funcs"
+)
+
+// ExecutePageContext is ExecutePage, with the context of the execution.
+// The props computed by Config.GlobalPropsProvider for the context are included, eg the current user of the request,
+// props provided at execution taking precedence over them, and the context is provided to Config.ContextFuncs.
+// Once the context is canceled or its deadline exceeded, the execution is aborted, returning the context's error.
+func (tm *Templater) ExecutePageContext(ctx context.Context, name string, kvs ...any) ([]byte, error) {
+	tm = tm.withContext(ctx)
+
+	props, err := tm.newContextProps(ctx, "page", name, kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		return ec.executePage(name, props)
+	}))
+}
+
+// ExecuteComponentContext is ExecuteComponent, with the context of the execution, as with ExecutePageContext.
+func (tm *Templater) ExecuteComponentContext(ctx context.Context, name string, kvs ...any) ([]byte, error) {
+	tm = tm.withContext(ctx)
+
+	props, err := tm.newContextProps(ctx, "component", name, kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm.finishOutput(tm.newContext().executeComponent(name, props))
+}
+
+// withContext returns a copy of the Templater executing with the context.
+func (tm *Templater) withContext(ctx context.Context) *Templater {
+	cpy := *tm
+	cpy.ctx = ctx
+	return &cpy
+}
+
+// newContextProps constructs the props of a top-level execution with a context,
+// failing if the context is already done.
+func (tm *Templater) newContextProps(ctx context.Context, kind, name string, kvs ...any) (map[string]any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tm.addGlobalProps(ctx, props); err != nil {
+		return nil, fmt.Errorf("failed to execute %s %s: %w", kind, name, err)
+	}
+
+	return props, nil
+}
+
+// context returns the context of the execution, or context.Background() if none was provided.
+func (ec *executionContext) context() context.Context {
+	if ec.ctx == nil {
+		return context.Background()
+	}
+	return ec.ctx
+}
+
+// contextErr returns the error of the context of the execution, if it's done.
+func (ec *executionContext) contextErr() error {
+	if ec.ctx == nil {
+		return nil
+	}
+	return ec.ctx.Err()
+}
+
+// cancelFuncCalls wraps each function of m to fail once the context of the execution is done,
+// aborting the execution. Executions without a context that may be done are left as is.
+func (ec *executionContext) cancelFuncCalls(m template.FuncMap) template.FuncMap {
+	if ec.ctx == nil || ec.ctx.Done() == nil {
+		return m
+	}
+
+	return funcs.Wrap(m, func(name string, fn any) any {
+		return funcs.Decorate(fn, func(args []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value {
+			if err := ec.ctx.Err(); err != nil {
+				// as with limitFuncCalls, text/template returns the panic as an execution error
+				panic(err)
+			}
+
+			return call(args)
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package templater
+
+import (
+	"context"
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecuteContext(t *testing.T) {
+	type requestIDKey struct{}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		ContextFuncs: func(ctx context.Context, name string, props map[string]any) template.FuncMap {
+			return template.FuncMap{
+				"requestID": func() string {
+					id, _ := ctx.Value(requestIDKey{}).(string)
+					return id
+				},
+				"cancel": func() string {
+					if cancel, ok := props["Cancel"].(context.CancelFunc); ok {
+						cancel()
+					}
+					return ""
+				},
+			}
+		},
+	})
+	require.NoError(t, tm.Override(RenderKindPage, "request_page", `<p>{{ requestID }}</p>{{ cancel }}{{ component "badge" "Label" "a" }}`))
+
+	t.Run("Given a context "+
+		"Then it's provided to the context funcs", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+
+		b, err := tm.ExecutePageContext(ctx, "request_page")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Contains(t, string(b), "<p>req-1</p>", "unexpected output")
+		assert.Contains(t, string(b), `<div class="badge">a</div>`, "unexpected output")
+	})
+
+	t.Run("Given no context "+
+		"Then the context funcs are given the background context", func(t *testing.T) {
+		b, err := tm.ExecuteInline(`<p>{{ requestID }}</p>`)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, "<p></p>", string(b), "unexpected output")
+	})
+
+	t.Run("Given the context canceled mid-execution "+
+		"Then the execution is aborted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err := tm.ExecutePageContext(ctx, "request_page", "Cancel", cancel)
+		assert.ErrorIs(t, err, context.Canceled, "unexpected error returned: %+v", err)
+	})
+
+	t.Run("Given the context canceled within a component "+
+		"Then the execution is aborted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		require.NoError(t, tm.RegisterComponent("canceling", `{{ cancel }}{{ requestID }}`))
+
+		_, err := tm.ExecuteComponentContext(ctx, "canceling", "Cancel", cancel)
+		assert.ErrorIs(t, err, context.Canceled, "unexpected error returned: %+v", err)
+	})
+
+	t.Run("Given a context already done "+
+		"Then nothing is executed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := tm.ExecuteComponentContext(ctx, "badge", "Label", "a")
+		assert.ErrorIs(t, err, context.Canceled, "unexpected error returned: %+v", err)
+	})
+}
@@ -0,0 +1,187 @@
+package templater
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// propTag is the struct tag naming the prop of a field, eg `prop:"title"`, or excluding it, `prop:"-"`.
+const propTag = "prop"
+
+// ExecutePageData is ExecutePage, with the props being the exported fields of data, a struct or pointer to one.
+// Fields are keyed by their name, or by the name of their `prop` struct tag, and fields tagged `prop:"-"` are skipped.
+// If the page has a schema file, eg user.schema.json alongside user.html.tmpl, the fields are checked against it,
+// returning an *ErrInvalidProp for a required prop without a field, or a field of a type other than that declared,
+// rather than executing the page with zero values in their place.
+func (tm *Templater) ExecutePageData(name string, data any) ([]byte, error) {
+	props, types, err := structProps(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute page %s: %w", name, err)
+	}
+
+	if err := tm.validatePageData(name, props, types); err != nil {
+		return nil, err
+	}
+
+	return tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		return ec.executePage(name, props)
+	}))
+}
+
+// structProps converts the exported fields of a struct, or pointer to one, to props,
+// returning the types of the fields alongside them.
+// The fields of embedded structs are promoted, as with field selectors.
+func structProps(data any) (map[string]any, map[string]reflect.Type, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil, fmt.Errorf("expected a struct: received a nil %s", v.Type())
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("expected a struct: received a %T", data)
+	}
+
+	props := make(map[string]any)
+	types := make(map[string]reflect.Type)
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		name := f.Name
+		if tag, ok := f.Tag.Lookup(propTag); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			// promoted through a nil embedded pointer
+			continue
+		}
+
+		props[name] = fv.Interface()
+		types[name] = f.Type
+	}
+
+	return props, types, nil
+}
+
+// validatePageData checks the props of the page of the given name against its schema file, if it has one.
+func (tm *Templater) validatePageData(name string, props map[string]any, types map[string]reflect.Type) error {
+	ec := tm.newContext()
+
+	if _, overridden := ec.caches.override(RenderKindPage, name); overridden {
+		return nil
+	}
+
+	pageDir := path.Join(tm.cfg.Dirs.Base, tm.cfg.Dirs.Pages)
+
+	match, _, err := ec.findTemplateFile(name, pageDir, props)
+	if err != nil {
+		return err
+	}
+
+	schemaFile := path.Join(pageDir, strings.TrimSuffix(match, tm.cfg.FileExt)+".schema.json")
+
+	schema, err := readSchema(tm.cfg.FS, schemaFile, name)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	for _, prop := range schema.Required {
+		if _, ok := props[prop]; !ok {
+			return &ErrInvalidProp{Name: name, Prop: prop}
+		}
+	}
+
+	for prop, raw := range schema.Properties {
+		t, ok := types[prop]
+		if !ok {
+			continue
+		}
+
+		var property struct {
+			Type json.RawMessage `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &property); err != nil {
+			return fmt.Errorf("invalid schema of %s: property %s: %w", name, prop, err)
+		}
+		if property.Type == nil {
+			continue
+		}
+
+		var expected []string
+		if err := json.Unmarshal(property.Type, &expected); err != nil {
+			var single string
+			if err := json.Unmarshal(property.Type, &single); err != nil {
+				return fmt.Errorf("invalid schema of %s: property %s: type must be a string or array of strings", name, prop)
+			}
+			expected = []string{single}
+		}
+
+		actual := schemaType(t, props[prop])
+		if !schemaTypeMatches(expected, actual) {
+			return &ErrInvalidProp{
+				Name:     name,
+				Prop:     prop,
+				Expected: strings.Join(expected, " or "),
+				Actual:   actual,
+			}
+		}
+	}
+
+	return nil
+}
+
+// schemaType returns the json schema type of a field of type t, eg "string" for a string field.
+// The type of interface fields is that of their value.
+func schemaType(t reflect.Type, value any) string {
+	if t.Kind() == reflect.Interface {
+		if value == nil {
+			return "null"
+		}
+		t = reflect.TypeOf(value)
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return t.String()
+	}
+}
+
+// schemaTypeMatches reports whether actual satisfies one of the expected json schema types,
+// integers satisfying "number" as well.
+func schemaTypeMatches(expected []string, actual string) bool {
+	for _, e := range expected {
+		if e == actual || (e == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecutePageData(t *testing.T) {
+	type (
+		Meta struct {
+			Count int
+		}
+		Page struct {
+			Meta
+			Title  string
+			Secret string `prop:"-"`
+			hidden string
+		}
+		TaggedPage struct {
+			Heading string `prop:"Title"`
+			Count   int
+		}
+		MistypedPage struct {
+			Title string
+			Count string
+		}
+		UntitledPage struct {
+			Count int
+		}
+		Args struct {
+			Name string
+			Data any
+		}
+		Expected struct {
+			Output string
+			Error  *ErrInvalidProp
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given a struct " +
+				"Then its exported fields are the props, including those of embedded structs",
+			Args: Args{
+				Name: "data_page",
+				Data: Page{Meta: Meta{Count: 3}, Title: "Cart", Secret: "s", hidden: "h"},
+			},
+			Expected: Expected{
+				Output: "<h1>Cart</h1>\n<p>3 items</p>\n",
+			},
+		},
+		{
+			Name: "Given a pointer to a struct with tagged fields " +
+				"Then the fields are keyed by their tags",
+			Args: Args{
+				Name: "data_page",
+				Data: &TaggedPage{Heading: "Cart", Count: 1},
+			},
+			Expected: Expected{
+				Output: "<h1>Cart</h1>\n<p>1 items</p>\n",
+			},
+		},
+		{
+			Name: "Given a field of a type other than that declared by the schema " +
+				"Then an error is returned",
+			Args: Args{
+				Name: "data_page",
+				Data: MistypedPage{Title: "Cart", Count: "3"},
+			},
+			Expected: Expected{
+				Error: &ErrInvalidProp{Name: "data_page", Prop: "Count", Expected: "integer", Actual: "string"},
+			},
+		},
+		{
+			Name: "Given no field for a prop required by the schema " +
+				"Then an error is returned",
+			Args: Args{
+				Name: "data_page",
+				Data: UntitledPage{Count: 3},
+			},
+			Expected: Expected{
+				Error: &ErrInvalidProp{Name: "data_page", Prop: "Title"},
+			},
+		},
+		{
+			Name: "Given a page without a schema " +
+				"Then the fields aren't validated",
+			Args: Args{
+				Name: "user_page",
+				Data: struct{ User int }{User: 7},
+			},
+			Expected: Expected{
+				Output: "<p>Signed in as 7</p>",
+			},
+		},
+	}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b, err := tm.ExecutePageData(test.Args.Name, test.Args.Data)
+
+			if test.Expected.Error == nil {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Contains(t, string(b), test.Expected.Output, "unexpected output")
+			} else {
+				var pe *ErrInvalidProp
+				require.ErrorAs(t, err, &pe, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Error, pe, "unexpected error returned")
+			}
+		})
+	}
+
+	t.Run("Given data other than a struct "+
+		"Then an error is returned", func(t *testing.T) {
+		_, err := tm.ExecutePageData("data_page", map[string]any{"Title": "Cart"})
+		assert.ErrorContains(t, err, "expected a struct", "unexpected error returned")
+	})
+}
@@ -0,0 +1,106 @@
+package templater
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"text/template/parse"
+)
+
+// DependencyUnknown is listed by DependencyGraph among the dependencies of templates using components
+// with names determined at execution, eg {{ component .Name }}.
+const DependencyUnknown = "unknown"
+
+// dependencyGraph caches the result of DependencyGraph.
+type dependencyGraph struct {
+	mu    sync.Mutex
+	graph map[string][]string
+}
+
+// DependencyGraph statically analyses the layout, page, and component files for uses of components,
+// returning the component files each template file uses, eg to invalidate caches or pre-render pages selectively.
+// Files are identified by their path relative to Dirs.Base, without the extension, eg "pages/home" uses "components/card".
+// Dependencies are sorted, and templates using components with names determined at execution also list DependencyUnknown.
+// Names matching no component file are omitted, as are components registered via RegisterComponent.
+// The graph is built once, then rebuilt only when polling detects a template file change.
+func (tm *Templater) DependencyGraph() (map[string][]string, error) {
+	dg := &tm.caches.dependencies
+
+	dg.mu.Lock()
+	defer dg.mu.Unlock()
+
+	if dg.graph == nil {
+		graph, err := tm.buildDependencyGraph()
+		if err != nil {
+			return nil, err
+		}
+		dg.graph = graph
+	}
+
+	graph := make(map[string][]string, len(dg.graph))
+	for k, deps := range dg.graph {
+		graph[k] = slices.Clone(deps)
+	}
+
+	return graph, nil
+}
+
+// invalidate discards the cached graph, for it to be rebuilt when next needed.
+func (dg *dependencyGraph) invalidate() {
+	dg.mu.Lock()
+	defer dg.mu.Unlock()
+
+	dg.graph = nil
+}
+
+func (tm *Templater) buildDependencyGraph() (map[string][]string, error) {
+	componentDir := path.Join(tm.cfg.Dirs.Base, tm.cfg.Dirs.Components)
+
+	files := []string{"layout"}
+	for _, dir := range []string{tm.cfg.Dirs.Pages, tm.cfg.Dirs.Components} {
+		names, err := listTemplateFiles(tm.cfg.FS, path.Join(tm.cfg.Dirs.Base, dir), tm.cfg.FileExt)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			files = append(files, path.Join(dir, name))
+		}
+	}
+
+	ec := tm.newContext()
+	graph := make(map[string][]string, len(files))
+	for _, f := range files {
+		b, err := fs.ReadFile(tm.cfg.FS, path.Join(tm.cfg.Dirs.Base, f+tm.cfg.FileExt))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file: %w", err)
+		}
+
+		t := parse.New(f)
+		t.Mode = parse.SkipFuncCheck
+		trees := make(map[string]*parse.Tree)
+		if _, err := t.Parse(string(b), "", "", trees); err != nil {
+			return nil, fmt.Errorf("failed to parse template file: %w", err)
+		}
+
+		deps := make(map[string]bool)
+		for _, tree := range trees {
+			walkComponentNames(tree.Root, func(name string, ok bool) {
+				if !ok {
+					deps[DependencyUnknown] = true
+					return
+				}
+				if match, _, err := ec.findTemplateFile(name, componentDir, map[string]any{}); err == nil {
+					deps[path.Join(tm.cfg.Dirs.Components, strings.TrimSuffix(match, tm.cfg.FileExt))] = true
+				}
+			})
+		}
+
+		graph[f] = slices.Sorted(maps.Keys(deps))
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,50 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_DependencyGraph(t *testing.T) {
+	t.Run("Given nested components "+
+		"Then each template lists the components it uses", func(t *testing.T) {
+		tm := new(Templater).With(Config{
+			Dirs: DirsConfig{
+				Base:       "test_dir/test_templates",
+				Pages:      "test_pages",
+				Components: "test_components",
+			},
+		})
+
+		graph, err := tm.DependencyGraph()
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+
+		assert.Equal(t, []string{"test_components/outer_component"}, graph["test_pages/nested_page"], "unexpected page dependencies")
+		assert.Equal(t, []string{"test_components/inner_component"}, graph["test_components/outer_component"], "unexpected component dependencies")
+		assert.Contains(t, graph, "test_components/inner_component", "expected every template file to be listed")
+		assert.Empty(t, graph["test_components/inner_component"], "unexpected component dependencies")
+	})
+
+	t.Run("Given components used with dynamic names "+
+		"Then they're listed, and the dynamic names as unknown", func(t *testing.T) {
+		tm := new(Templater).With(Config{
+			Dirs: DirsConfig{
+				Base:       "test_dir/dynamic_templates",
+				Pages:      "pages",
+				Components: "components",
+			},
+		})
+
+		graph, err := tm.DependencyGraph()
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+
+		assert.Equal(t, map[string][]string{
+			"layout":           nil,
+			"pages/home":       {"components/used", DependencyUnknown},
+			"components/chart": nil,
+			"components/used":  nil,
+		}, graph, "unexpected graph")
+	})
+}
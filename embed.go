@@ -0,0 +1,52 @@
+package templater
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"path"
+)
+
+// NewFromEmbed returns a Templater reading its templates from the embedded files, as Config.FS,
+// having parsed every layout, page, and component file, so malformed templates fail at startup
+// rather than on first request. The errors of all those malformed are returned together.
+// Components are checked as Validate checks them, their schemas included.
+func NewFromEmbed(fsys embed.FS, cfg Config) (*Templater, error) {
+	cfg.FS = fsys
+
+	tm := new(Templater).With(cfg)
+	if err := errors.Join(tm.validatePages(), tm.Validate()); err != nil {
+		tm.Close()
+		return nil, err
+	}
+
+	return tm, nil
+}
+
+// validatePages checks that the layout and every page file parses, returning the errors of all those that don't.
+func (tm *Templater) validatePages() error {
+	ec := tm.newContext()
+
+	var errs []error
+	if _, err := ec.parseLayout("", map[string]any{}); err != nil {
+		errs = append(errs, err)
+	}
+
+	pageDir := path.Join(tm.cfg.Dirs.Base, tm.cfg.Dirs.Pages)
+
+	pages, err := listTemplateFiles(tm.cfg.FS, pageDir, tm.cfg.FileExt)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+
+	for _, name := range pages {
+		t := template.New(name).
+			Funcs(ec.buildFuncMap(name, map[string]any{}))
+		if _, err := parseTemplateFile(t, tm.cfg.FS, path.Join(pageDir, name+tm.cfg.FileExt), path.Base(name+tm.cfg.FileExt)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid page %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
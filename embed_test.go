@@ -0,0 +1,48 @@
+package templater
+
+import (
+	"embed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed test_dir/unused_templates test_dir/malformed_templates
+var embeddedTemplates embed.FS
+
+func TestNewFromEmbed(t *testing.T) {
+	t.Run("Given well formed templates "+
+		"Then they're executed from the embedded files", func(t *testing.T) {
+		tm, err := NewFromEmbed(embeddedTemplates, Config{
+			Dirs: DirsConfig{
+				Base:       "test_dir/unused_templates",
+				Pages:      "pages",
+				Components: "components",
+			},
+		})
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		defer tm.Close()
+
+		b, err := tm.ExecuteComponent("card", "Title", "Ada")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, "<div>Ada</div>\n", string(b), "unexpected output")
+	})
+
+	t.Run("Given malformed templates "+
+		"Then an error listing each of them is returned", func(t *testing.T) {
+		_, err := NewFromEmbed(embeddedTemplates, Config{
+			Dirs: DirsConfig{
+				Base:       "test_dir/malformed_templates",
+				Pages:      "pages",
+				Components: "components",
+			},
+		})
+		require.Error(t, err, "expected an error to be returned")
+
+		assert.ErrorContains(t, err, "invalid page home", "expected the malformed page to be listed")
+		assert.ErrorContains(t, err, "invalid component card", "expected the component using an undefined func to be listed")
+		assert.ErrorContains(t, err, "invalid component footer", "expected the malformed component to be listed")
+		assert.NotContains(t, err.Error(), "about", "expected the well formed page not to be listed")
+	})
+}
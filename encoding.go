@@ -0,0 +1,51 @@
+package templater
+
+import (
+	"fmt"
+	"regexp"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// metaCharsetPattern matches the charset of <meta charset="..."> tags.
+var metaCharsetPattern = regexp.MustCompile(`(?i)(<meta\s+charset\s*=\s*["']?)[^"'\s/>]+`)
+
+// encodeOutput encodes the UTF-8 output in the named character encoding,
+// replacing the charset of any <meta charset> tag with the encoding's name.
+func encodeOutput(b []byte, name string) ([]byte, error) {
+	enc, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown output encoding %q: %w", name, err)
+	}
+
+	canonical, err := htmlindex.Name(enc)
+	if err != nil {
+		return nil, fmt.Errorf("unknown output encoding %q: %w", name, err)
+	}
+
+	b = metaCharsetPattern.ReplaceAll(b, []byte("${1}"+canonical))
+
+	encoded, err := encoding.HTMLEscapeUnsupported(enc.NewEncoder()).Bytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output as %s: %w", canonical, err)
+	}
+
+	return encoded, nil
+}
+
+// contentCharset returns the charset of the output, for the Content-Type header.
+func (c *Config) contentCharset() string {
+	if c.OutputEncoding == "" {
+		return "utf-8"
+	}
+
+	enc, err := htmlindex.Get(c.OutputEncoding)
+	if err != nil {
+		return c.OutputEncoding
+	}
+	if name, err := htmlindex.Name(enc); err == nil {
+		return name
+	}
+	return c.OutputEncoding
+}
@@ -0,0 +1,35 @@
+package templater
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_OutputEncoding(t *testing.T) {
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		OutputEncoding: "cp1252",
+	}
+	tm := new(Templater).With(cfg)
+
+	b, err := tm.ExecuteInline(`<meta charset="utf-8"><p>{{ .Text }}</p>`, "Text", "Café €5 ✓")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, []byte("<meta charset=\"windows-1252\"><p>Caf\xe9 \x805 &#10003;</p>"), b, "unexpected bytes returned")
+
+	w := httptest.NewRecorder()
+	require.NoError(t, tm.RenderToResponse(w, httptest.NewRequest(http.MethodGet, "/simple_page", nil), "simple_page"))
+	assert.Equal(t, "text/html; charset=windows-1252", w.Header().Get("Content-Type"), "unexpected content type")
+
+	cfg.OutputEncoding = "no-such-encoding"
+	_, err = new(Templater).With(cfg).ExecuteInline(`<p></p>`)
+	assert.Error(t, err, "expected an error for an unknown encoding")
+}
@@ -1,6 +1,9 @@
 package templater
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type (
 	// ErrNotTemplateFileFound occurs when the template was not found
@@ -15,6 +18,46 @@ type (
 		Type  string
 		Err   error
 	}
+
+	// ErrComponentDisabled is returned when executing a component listed in Config.DisabledComponents
+	ErrComponentDisabled struct {
+		Name string
+	}
+
+	// ErrBlockNotDefined is returned when executing a block not defined by a page
+	ErrBlockNotDefined struct {
+		Page  string
+		Block string
+	}
+
+	// ErrMaxDepthExceeded is returned when components or slots are nested deeper than Config.MaxDepth
+	ErrMaxDepthExceeded struct {
+		Name     string
+		MaxDepth int
+	}
+
+	// ErrLimitExceeded is returned when an execution exceeds Config.MaxRenders, Config.MaxFuncCalls, or
+	// Config.MaxComponentInstances, Limit being LimitRenders, LimitFuncCalls, or LimitComponentInstances respectively.
+	// Name is that of the component exceeding Config.MaxComponentInstances
+	ErrLimitExceeded struct {
+		Limit string
+		Max   int
+		Name  string
+	}
+
+	// ErrDuplicateIDs is returned when Config.CheckDuplicateIDs is set and the output uses an id more than once
+	ErrDuplicateIDs struct {
+		IDs []string
+	}
+
+	// ErrInvalidProp is returned when a prop doesn't satisfy the schema of the page or component it's provided to.
+	// Actual is the type of the prop, or empty when a required prop is missing
+	ErrInvalidProp struct {
+		Name     string
+		Prop     string
+		Expected string
+		Actual   string
+	}
 )
 
 func (e *ErrNotTemplateFileFound) Error() string {
@@ -38,3 +81,33 @@ func (e *ErrInvalidWildcardValue) wrap(err error) *ErrInvalidWildcardValue {
 	e.Err = err
 	return e
 }
+
+func (e *ErrComponentDisabled) Error() string {
+	return fmt.Sprintf("component %s is disabled", e.Name)
+}
+
+func (e *ErrMaxDepthExceeded) Error() string {
+	return fmt.Sprintf("maximum depth of %d exceeded while executing %s", e.MaxDepth, e.Name)
+}
+
+func (e *ErrLimitExceeded) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("limit of %d %s exceeded by %s", e.Max, e.Limit, e.Name)
+	}
+	return fmt.Sprintf("limit of %d %s exceeded", e.Max, e.Limit)
+}
+
+func (e *ErrBlockNotDefined) Error() string {
+	return fmt.Sprintf("block %s not defined by page %s", e.Block, e.Page)
+}
+
+func (e *ErrDuplicateIDs) Error() string {
+	return fmt.Sprintf("duplicate ids in output: %s", strings.Join(e.IDs, ", "))
+}
+
+func (e *ErrInvalidProp) Error() string {
+	if e.Actual == "" {
+		return fmt.Sprintf("prop %s of %s is missing: it's required by the schema", e.Prop, e.Name)
+	}
+	return fmt.Sprintf("prop %s of %s is of type %s: the schema declares it of type %s", e.Prop, e.Name, e.Actual, e.Expected)
+}
@@ -0,0 +1,18 @@
+package templater
+
+// WithExperiments returns a copy of the Templater which provides the experiment variants assigned to the request,
+// by experiment name, eg {"new-nav": "treatment"}, to the `experiment` template function of every execution.
+// It complements Config.VariantSelector, allowing a single template to branch on the variant inline.
+func (tm *Templater) WithExperiments(assignments map[string]string) *Templater {
+	cpy := *tm
+	cpy.experiments = assignments
+	return &cpy
+}
+
+// experiment is the implementation of the `experiment` template function.
+// It returns the variant of the experiment assigned via WithExperiments, or empty if unassigned, eg
+//
+//	{{ if eq (experiment "new-nav") "treatment" }}...{{ else }}...{{ end }}
+func (ec *executionContext) experiment(name string) string {
+	return ec.experiments[name]
+}
@@ -0,0 +1,32 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_WithExperiments(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	const body = `{{ experiment "new-nav" }}:{{ if eq (experiment "new-nav") "treatment" }}<nav class="new"></nav>{{ else }}<nav></nav>{{ end }}`
+
+	b, err := tm.WithExperiments(map[string]string{"new-nav": "treatment"}).ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `treatment:<nav class="new"></nav>`, string(b), "unexpected bytes returned")
+
+	b, err = tm.WithExperiments(map[string]string{"new-nav": "control"}).ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `control:<nav></nav>`, string(b), "unexpected bytes returned")
+
+	b, err = tm.ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `:<nav></nav>`, string(b), "expected no variant without an assignment")
+}
@@ -0,0 +1,48 @@
+package templater
+
+import "slices"
+
+// framesKey is the key the ids of the `frame` template function are stored under.
+const framesKey = "frames"
+
+// ExecutePageWithFrames is ExecutePage, additionally returning the ids registered by the `frame` template function,
+// in order of first registration.
+// They're intended as a manifest of the fragments of the page a client may swap, eg HTMX or Turbo frames.
+func (tm *Templater) ExecutePageWithFrames(name string, kvs ...any) ([]byte, []string, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var frames []string
+	b, err := tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		b, err := ec.executePage(name, props)
+		frames = ec.render.frames()
+		return b, err
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, frames, nil
+}
+
+// frame is the implementation of the `frame` template function.
+// It registers the id as that of a swappable fragment, returning it for use as the fragment's element id, eg
+//
+//	<turbo-frame id="{{ frame "cart" }}">{{ component "cart" }}</turbo-frame>
+func (rs *renderState) frame(id string) string {
+	rs.storeValue(framesKey, id)
+	return id
+}
+
+// frames returns the ids registered by the `frame` template function, without repeats.
+func (rs *renderState) frames() []string {
+	var ids []string
+	for _, v := range rs.store[framesKey] {
+		if id := v.(string); !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
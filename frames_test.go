@@ -0,0 +1,25 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecutePageWithFrames(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, frames, err := tm.ExecutePageWithFrames("framed_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Contains(t, string(b), `<section id="cart">`, "unexpected bytes returned")
+	assert.Contains(t, string(b), `<section id="recommendations">`, "unexpected bytes returned")
+	assert.Equal(t, []string{"cart", "recommendations"}, frames, "unexpected frames returned")
+}
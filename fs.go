@@ -0,0 +1,45 @@
+package templater
+
+import (
+	"html/template"
+	"io/fs"
+	"os"
+)
+
+// osFS is the default Config.FS, opening files by their paths on the operating system's filesystem as is,
+// so Dirs.Base may be absolute, or relative to the working directory.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+// subFS returns the directory of fsys as an fs.FS of its own, its paths relative to the directory.
+func subFS(fsys fs.FS, dir string) (fs.FS, error) {
+	switch f := fsys.(type) {
+	case osFS:
+		return os.DirFS(dir), nil
+	case *overlayFS:
+		return overlayDirFS{o: f, dir: dir}, nil
+	}
+	return fs.Sub(fsys, dir)
+}
+
+// parseTemplateFile parses the file of fsys into t, as t.ParseFiles would the file of the operating system,
+// the file's template being named name, eg its base name.
+func parseTemplateFile(t *template.Template, fsys fs.FS, filename, name string) (*template.Template, error) {
+	b, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := t
+	if name != t.Name() {
+		tmpl = t.New(name)
+	}
+	if _, err := tmpl.Parse(string(b)); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
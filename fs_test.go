@@ -0,0 +1,64 @@
+package templater
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_FS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/layout.html.tmpl": {
+			Data: []byte(`<html><body>{{ block "body" . }}{{ end }}</body></html>`),
+		},
+		"templates/pages/users/{id}.html.tmpl": {
+			Data: []byte(`<h1>User {{ .PathParams.id }}</h1>{{ component "card" "Title" .Title }}`),
+		},
+		"templates/components/card.html.tmpl": {
+			Data: []byte(`<div class="card">{{ .Title }}</div>`),
+		},
+	}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "templates",
+			Pages:      "pages",
+			Components: "components",
+		},
+		FS: fsys,
+	})
+
+	t.Run("Given a page "+
+		"Then the layout, page, and components are read from the filesystem", func(t *testing.T) {
+		b, err := tm.ExecutePage("users/42", "Title", "Ada")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, `<html><body><h1>User 42</h1><div class="card">Ada</div></body></html>`, string(b), "unexpected output")
+	})
+
+	t.Run("Given a component "+
+		"Then it's read from the filesystem", func(t *testing.T) {
+		b, err := tm.ExecuteComponent("card", "Title", "Ada")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, `<div class="card">Ada</div>`, string(b), "unexpected output")
+	})
+
+	t.Run("Given a component missing from the filesystem "+
+		"Then an error is returned", func(t *testing.T) {
+		_, err := tm.ExecuteComponent("no_such/component")
+		var te *ErrNotTemplateFileFound
+		assert.ErrorAs(t, err, &te, "unexpected error returned: %+v", err)
+	})
+
+	t.Run("Given the filesystem "+
+		"Then its template files are analysed", func(t *testing.T) {
+		graph, err := tm.DependencyGraph()
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, map[string][]string{
+			"layout":           nil,
+			"pages/users/{id}": {"components/card"},
+			"components/card":  nil,
+		}, graph, "unexpected graph")
+	})
+}
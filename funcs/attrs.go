@@ -0,0 +1,97 @@
+package funcs
+
+import (
+	"fmt"
+	"html/template"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DataAttrs is the implementation of the `dataAttrs` template function.
+// It emits a data-* attribute for each entry of m, ordered by key, eg
+//
+//	<div {{ dataAttrs (props "userId" 7 "role" "admin") }}>
+//
+// results in <div data-role="admin" data-user-id="7">.
+// Keys are converted to kebab case, and values are stringified and escaped.
+func DataAttrs(m map[string]any) template.HTMLAttr {
+	attrs := make([]string, 0, len(m))
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		attrs = append(attrs, fmt.Sprintf(`data-%s="%s"`, kebabCase(k), template.HTMLEscapeString(fmt.Sprint(m[k]))))
+	}
+	return template.HTMLAttr(strings.Join(attrs, " "))
+}
+
+// kebabCase converts camel case, snake case, and space separated words to lower kebab case,
+// dropping any characters not valid in an attribute name.
+func kebabCase(s string) string {
+	var b strings.Builder
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || unicode.IsSpace(r):
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+				b.WriteByte('-')
+			}
+		case unicode.IsUpper(r):
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if (prevLower || nextLower) && !strings.HasSuffix(b.String(), "-") {
+				b.WriteByte('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == ':':
+			b.WriteRune(r)
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// AriaExpanded is the implementation of the `ariaExpanded` template function.
+// It emits the aria-expanded attribute, eg <button {{ ariaExpanded .Open }}> results in <button aria-expanded="false">.
+func AriaExpanded(expanded bool) template.HTMLAttr {
+	return template.HTMLAttr(fmt.Sprintf(`aria-expanded="%t"`, expanded))
+}
+
+// AriaHidden is the implementation of the `ariaHidden` template function.
+// It emits aria-hidden="true" if hidden, and nothing otherwise, as hidden is the only value that changes anything.
+func AriaHidden(hidden bool) template.HTMLAttr {
+	if !hidden {
+		return ""
+	}
+	return `aria-hidden="true"`
+}
+
+// AriaControls is the implementation of the `ariaControls` template function.
+// It emits the aria-controls attribute referencing the element id, or nothing if id is empty.
+func AriaControls(id string) template.HTMLAttr {
+	if id == "" {
+		return ""
+	}
+	return template.HTMLAttr(fmt.Sprintf(`aria-controls="%s"`, template.HTMLEscapeString(id)))
+}
+
+// Aria is the implementation of the `aria` template function.
+// It emits an aria-* attribute for each entry of m, ordered by key, eg
+//
+//	<div {{ aria (props "labelledby" "title" "modal" true "describedby" "") }}>
+//
+// results in <div aria-labelledby="title" aria-modal="true">.
+// Keys are converted to kebab case, and may include the aria- prefix. Nil values and empty strings are omitted.
+func Aria(m map[string]any) template.HTMLAttr {
+	attrs := make([]string, 0, len(m))
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		v := m[k]
+		if v == nil || v == "" {
+			continue
+		}
+
+		name := strings.TrimPrefix(kebabCase(k), "aria-")
+		attrs = append(attrs, fmt.Sprintf(`aria-%s="%s"`, name, template.HTMLEscapeString(fmt.Sprint(v))))
+	}
+	return template.HTMLAttr(strings.Join(attrs, " "))
+}
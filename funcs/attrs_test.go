@@ -0,0 +1,57 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataAttrs(t *testing.T) {
+	assert.Equal(t,
+		template.HTMLAttr(`data-title="&#34;Quoted&#34; &lt;b&gt;" data-user-id="7"`),
+		DataAttrs(map[string]any{
+			"userId": 7,
+			"title":  `"Quoted" <b>`,
+		}),
+		"unexpected attributes returned",
+	)
+}
+
+func TestKebabCase(t *testing.T) {
+	for in, out := range map[string]string{
+		"userId":     "user-id",
+		"user_id":    "user-id",
+		"User Name":  "user-name",
+		"HTMLParser": "html-parser",
+		"id":         "id",
+		"a\"b":       "ab",
+	} {
+		assert.Equal(t, out, kebabCase(in), "unexpected kebab case of %q", in)
+	}
+}
+
+func TestAriaAttrs(t *testing.T) {
+	assert.Equal(t, template.HTMLAttr(`aria-expanded="false"`), AriaExpanded(false), "unexpected aria-expanded")
+	assert.Equal(t, template.HTMLAttr(`aria-expanded="true"`), AriaExpanded(true), "unexpected aria-expanded")
+
+	assert.Equal(t, template.HTMLAttr(`aria-hidden="true"`), AriaHidden(true), "unexpected aria-hidden")
+	assert.Empty(t, AriaHidden(false), "expected no aria-hidden")
+
+	assert.Equal(t, template.HTMLAttr(`aria-controls="menu-&lt;1&gt;"`), AriaControls("menu-<1>"), "unexpected aria-controls")
+	assert.Empty(t, AriaControls(""), "expected no aria-controls")
+}
+
+func TestAria(t *testing.T) {
+	assert.Equal(t,
+		template.HTMLAttr(`aria-label="Close &#34;dialog&#34;" aria-labelledby="title" aria-modal="true"`),
+		Aria(map[string]any{
+			"modal":       true,
+			"aria-label":  `Close "dialog"`,
+			"labelledby":  "title",
+			"describedby": "",
+			"owns":        nil,
+		}),
+		"unexpected attributes returned",
+	)
+}
@@ -0,0 +1,94 @@
+package funcs
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// BEM is the implementation of the `bem` template function.
+// It constructs the class list of a BEM block or element along with its modifiers, eg
+//
+//	{{ bem "card" "title" "large" "muted" }}
+//
+// results in "card__title card__title--large card__title--muted".
+// An empty element results in the block and its modifiers alone.
+func BEM(block, element string, modifiers ...string) string {
+	base := block
+	if element != "" {
+		base += "__" + element
+	}
+
+	classes := make([]string, 1, len(modifiers)+1)
+	classes[0] = base
+	for _, m := range modifiers {
+		if m != "" {
+			classes = append(classes, base+"--"+m)
+		}
+	}
+
+	return strings.Join(classes, " ")
+}
+
+// gridBreakpoints are the responsive breakpoint prefixes in order of increasing width.
+var gridBreakpoints = []string{"sm", "md", "lg", "xl", "2xl"}
+
+// gridGapPattern matches valid gap sizes, eg 4, 0.5, or px.
+var gridGapPattern = regexp.MustCompile(`^[a-z0-9.]+$`)
+
+// GridClasses is the implementation of the `gridClasses` template function.
+// It constructs the utility classes of a responsive CSS grid, eg
+//
+//	{{ gridClasses 2 "4" (props "md" 4 "lg" 6) }}
+//
+// results in "grid grid-cols-2 md:grid-cols-4 lg:grid-cols-6 gap-4".
+// breakpoints maps breakpoint prefixes to column counts, as a map[string]int or map[string]any of ints,
+// and may be nil. They're ordered by width, any unknown prefixes following the known ones alphabetically.
+// A column count less than one results in a single column, and an invalid gap in no gap class.
+func GridClasses(cols int, gap string, breakpoints any) (string, error) {
+	bps := make(map[string]int)
+	switch b := breakpoints.(type) {
+	case nil:
+	case map[string]int:
+		maps.Copy(bps, b)
+	case map[string]any:
+		for k, v := range b {
+			n, ok := v.(int)
+			if !ok {
+				return "", fmt.Errorf("gridClasses expected the column count of breakpoint %s to be an int: received a %T", k, v)
+			}
+			bps[k] = n
+		}
+	default:
+		return "", fmt.Errorf("gridClasses expected breakpoints to be a map: received a %T", breakpoints)
+	}
+
+	classes := []string{"grid", fmt.Sprintf("grid-cols-%d", max(cols, 1))}
+
+	prefixes := slices.Collect(maps.Keys(bps))
+	slices.SortFunc(prefixes, func(a, b string) int {
+		ai, bi := slices.Index(gridBreakpoints, a), slices.Index(gridBreakpoints, b)
+		switch {
+		case ai >= 0 && bi >= 0:
+			return cmp.Compare(ai, bi)
+		case ai >= 0:
+			return -1
+		case bi >= 0:
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	})
+	for _, p := range prefixes {
+		classes = append(classes, fmt.Sprintf("%s:grid-cols-%d", p, max(bps[p], 1)))
+	}
+
+	if gridGapPattern.MatchString(gap) {
+		classes = append(classes, "gap-"+gap)
+	}
+
+	return strings.Join(classes, " "), nil
+}
@@ -0,0 +1,155 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBEM(t *testing.T) {
+	type (
+		Args struct {
+			Block     string
+			Element   string
+			Modifiers []string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected string
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given a block, element, and two modifiers " +
+				"Then the element class and a class per modifier are returned",
+			Args: Args{
+				Block:     "card",
+				Element:   "title",
+				Modifiers: []string{"large", "muted"},
+			},
+			Expected: "card__title card__title--large card__title--muted",
+		},
+		{
+			Name: "Given a block and modifier " +
+				"With no element " +
+				"Then the block class and modifier class are returned",
+			Args: Args{
+				Block:     "card",
+				Modifiers: []string{"active"},
+			},
+			Expected: "card card--active",
+		},
+		{
+			Name: "Given a block and element " +
+				"With an empty modifier " +
+				"Then the empty modifier is ignored",
+			Args: Args{
+				Block:     "card",
+				Element:   "body",
+				Modifiers: []string{""},
+			},
+			Expected: "card__body",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, BEM(test.Args.Block, test.Args.Element, test.Args.Modifiers...), "unexpected classes returned")
+		})
+	}
+}
+
+func TestGridClasses(t *testing.T) {
+	type (
+		Args struct {
+			Cols        int
+			Gap         string
+			Breakpoints any
+		}
+		Expected struct {
+			Classes string
+			Error   bool
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given two breakpoints " +
+				"Then a class per breakpoint is returned in order of width",
+			Args: Args{
+				Cols:        2,
+				Gap:         "4",
+				Breakpoints: map[string]int{"lg": 6, "md": 4},
+			},
+			Expected: Expected{
+				Classes: "grid grid-cols-2 md:grid-cols-4 lg:grid-cols-6 gap-4",
+			},
+		},
+		{
+			Name: "Given breakpoints from props " +
+				"Then unknown breakpoints follow the known ones",
+			Args: Args{
+				Cols:        1,
+				Gap:         "0.5",
+				Breakpoints: map[string]any{"print": 3, "sm": 2},
+			},
+			Expected: Expected{
+				Classes: "grid grid-cols-1 sm:grid-cols-2 print:grid-cols-3 gap-0.5",
+			},
+		},
+		{
+			Name: "Given invalid column counts and gap " +
+				"Then single columns and no gap are returned",
+			Args: Args{
+				Cols:        0,
+				Gap:         "4; color: red",
+				Breakpoints: map[string]int{"md": -1},
+			},
+			Expected: Expected{
+				Classes: "grid grid-cols-1 md:grid-cols-1",
+			},
+		},
+		{
+			Name: "Given no breakpoints " +
+				"Then the base classes are returned",
+			Args: Args{
+				Cols: 3,
+			},
+			Expected: Expected{
+				Classes: "grid grid-cols-3",
+			},
+		},
+		{
+			Name: "Given a breakpoint column count that isn't an int " +
+				"Then an error is returned",
+			Args: Args{
+				Cols:        3,
+				Breakpoints: map[string]any{"md": "4"},
+			},
+			Expected: Expected{
+				Error: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			classes, err := GridClasses(test.Args.Cols, test.Args.Gap, test.Args.Breakpoints)
+
+			if !test.Expected.Error {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Classes, classes, "unexpected classes returned")
+			} else {
+				assert.Error(t, err, "expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package funcs
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// Collation is the BCP 47 language tag of the locale strings are sorted for, eg "de" or "sv".
+// The zero value sorts by the root collation, which suits most languages better than byte order.
+type Collation string
+
+// SortStrings is the implementation of the `sortStrings` template function.
+// It returns a copy of the strings, sorted for the locale, eg "Äpfel" before "Bananen" in German,
+// where byte order would place it last.
+func (c Collation) SortStrings(s []string) []string {
+	sorted := slices.Clone(s)
+	c.collator().SortStrings(sorted)
+	return sorted
+}
+
+// SortedKeys is the implementation of the `sortedKeys` template function.
+// It returns the keys of the map, which must have string keys, sorted for the locale, eg
+//
+//	{{ range sortedKeys .Cities }}<li>{{ . }}: {{ index $.Cities . }}</li>{{ end }}
+func (c Collation) SortedKeys(m any) ([]string, error) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("sortedKeys expected a map with string keys: received a %T", m)
+	}
+
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	c.collator().SortStrings(keys)
+
+	return keys, nil
+}
+
+func (c Collation) collator() *collate.Collator {
+	tag, err := language.Parse(string(c))
+	if err != nil {
+		tag = language.Und
+	}
+	return collate.New(tag)
+}
@@ -0,0 +1,29 @@
+package funcs
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollation_SortStrings(t *testing.T) {
+	words := []string{"Zucker", "Äpfel", "Bananen", "Öl", "apfel"}
+
+	byteSorted := slices.Clone(words)
+	slices.Sort(byteSorted)
+	assert.Equal(t, []string{"Bananen", "Zucker", "apfel", "Äpfel", "Öl"}, byteSorted, "unexpected byte order")
+
+	assert.Equal(t, []string{"apfel", "Äpfel", "Bananen", "Öl", "Zucker"}, Collation("de").SortStrings(words), "unexpected german order")
+	assert.Equal(t, []string{"Zucker", "Äpfel", "Bananen", "Öl", "apfel"}, words, "expected the strings not to be modified")
+}
+
+func TestCollation_SortedKeys(t *testing.T) {
+	keys, err := Collation("de").SortedKeys(map[string]int{"München": 1, "Berlin": 2, "Zürich": 3, "Mainz": 4})
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, []string{"Berlin", "Mainz", "München", "Zürich"}, keys, "unexpected keys returned")
+
+	_, err = Collation("de").SortedKeys([]string{"a"})
+	assert.Error(t, err, "expected an error for a non-map")
+}
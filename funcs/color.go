@@ -0,0 +1,152 @@
+package funcs
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ContrastBlack is the text color returned by ContrastColor for light backgrounds, and invalid colors.
+	ContrastBlack = "#000000"
+	// ContrastWhite is the text color returned by ContrastColor for dark backgrounds.
+	ContrastWhite = "#ffffff"
+)
+
+// ContrastColor is the implementation of the `contrastColor` template function.
+// It returns black or white, whichever contrasts most with the background color bg,
+// by the WCAG relative luminance of bg, eg
+//
+//	<div style="background: {{ .Color }}; color: {{ contrastColor .Color }}">
+//
+// bg may be a hex color, eg #fff or #1e90ff, or an rgb() or rgba() color, eg rgb(30, 144, 255).
+// Any alpha is ignored. Black is returned if bg is invalid.
+func ContrastColor(bg string) string {
+	r, g, b, ok := parseColor(bg)
+	if !ok {
+		return ContrastBlack
+	}
+
+	l := 0.2126*linearChannel(r) + 0.7152*linearChannel(g) + 0.0722*linearChannel(b)
+
+	// compare the contrast ratios of (l + 0.05) / (0 + 0.05) and (1 + 0.05) / (l + 0.05)
+	if (l+0.05)*(l+0.05) > 0.05*1.05 {
+		return ContrastBlack
+	}
+	return ContrastWhite
+}
+
+func linearChannel(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func parseColor(s string) (r, g, b uint8, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if hex, found := strings.CutPrefix(s, "#"); found {
+		switch len(hex) {
+		case 3, 4:
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		case 6, 8:
+			hex = hex[:6]
+		default:
+			return 0, 0, 0, false
+		}
+
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+	}
+
+	for _, prefix := range []string{"rgba(", "rgb("} {
+		args, found := strings.CutPrefix(s, prefix)
+		if !found {
+			continue
+		}
+		args, found = strings.CutSuffix(args, ")")
+		if !found {
+			return 0, 0, 0, false
+		}
+
+		parts := strings.FieldsFunc(args, func(c rune) bool {
+			return c == ',' || c == ' ' || c == '/'
+		})
+		if len(parts) != 3 && len(parts) != 4 {
+			return 0, 0, 0, false
+		}
+
+		var channels [3]uint8
+		for i := range channels {
+			v, err := strconv.ParseUint(parts[i], 10, 8)
+			if err != nil {
+				return 0, 0, 0, false
+			}
+			channels[i] = uint8(v)
+		}
+		return channels[0], channels[1], channels[2], true
+	}
+
+	return 0, 0, 0, false
+}
+
+// Palette is the colors ColorFromString selects from, eg brand colors for tags.
+// The zero value derives colors from the full range of hues instead.
+type Palette []string
+
+// ColorFromString is the implementation of the `colorFromString` template function, using no Palette.
+func ColorFromString(s string) string {
+	return Palette(nil).ColorFromString(s)
+}
+
+// ColorFromString returns a hex color derived from a hash of s, so the same string always gets the same color,
+// eg for the background of an avatar or tag, eg
+//
+//	<span class="tag" style="background: {{ colorFromString .Tag }}">{{ .Tag }}</span>
+//
+// The color is selected from the palette, if any. Otherwise it's of a hue derived from s,
+// of moderate saturation and lightness, so may be paired with `contrastColor` for text.
+func (p Palette) ColorFromString(s string) string {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	sum := h.Sum32()
+
+	if len(p) > 0 {
+		return p[sum%uint32(len(p))]
+	}
+
+	r, g, b := hslToRGB(float64(sum%360), 0.65, 0.40)
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// hslToRGB converts a color of the hue h in degrees, and saturation s and lightness l in [0, 1], to RGB.
+func hslToRGB(h, s, l float64) (r, g, b uint8) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var rf, gf, bf float64
+	switch {
+	case h < 60:
+		rf, gf, bf = c, x, 0
+	case h < 120:
+		rf, gf, bf = x, c, 0
+	case h < 180:
+		rf, gf, bf = 0, c, x
+	case h < 240:
+		rf, gf, bf = 0, x, c
+	case h < 300:
+		rf, gf, bf = x, 0, c
+	default:
+		rf, gf, bf = c, 0, x
+	}
+
+	return uint8(math.Round((rf + m) * 255)), uint8(math.Round((gf + m) * 255)), uint8(math.Round((bf + m) * 255))
+}
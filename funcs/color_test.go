@@ -0,0 +1,102 @@
+package funcs
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContrastColor(t *testing.T) {
+	type Test struct {
+		Name       string
+		Background string
+		Expected   string
+	}
+
+	tests := []Test{
+		{
+			Name: "Given a dark hex background " +
+				"Then white is returned",
+			Background: "#1a1a2e",
+			Expected:   ContrastWhite,
+		},
+		{
+			Name: "Given a light hex background " +
+				"Then black is returned",
+			Background: "#f5f5dc",
+			Expected:   ContrastBlack,
+		},
+		{
+			Name: "Given a short hex background " +
+				"Then it's expanded",
+			Background: "#00F",
+			Expected:   ContrastWhite,
+		},
+		{
+			Name: "Given a hex background with alpha " +
+				"Then the alpha is ignored",
+			Background: "#ffff0080",
+			Expected:   ContrastBlack,
+		},
+		{
+			Name: "Given a dark rgb background " +
+				"Then white is returned",
+			Background: "rgb(128, 0, 0)",
+			Expected:   ContrastWhite,
+		},
+		{
+			Name: "Given a light rgba background " +
+				"Then black is returned",
+			Background: "rgba(144, 238, 144, 0.5)",
+			Expected:   ContrastBlack,
+		},
+		{
+			Name: "Given an invalid background " +
+				"Then black is returned",
+			Background: "#zzzzzz",
+			Expected:   ContrastBlack,
+		},
+		{
+			Name: "Given an rgb background out of range " +
+				"Then black is returned",
+			Background: "rgb(300, 0, 0)",
+			Expected:   ContrastBlack,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, ContrastColor(test.Background), "unexpected color returned")
+		})
+	}
+}
+
+func TestPalette_ColorFromString(t *testing.T) {
+	hexColor := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+	t.Run("Given no palette "+
+		"Then a stable hex color is returned", func(t *testing.T) {
+		color := ColorFromString("golang")
+		assert.Regexp(t, hexColor, color, "unexpected color returned")
+		assert.Equal(t, color, ColorFromString("golang"), "expected the same color for the same input")
+	})
+
+	t.Run("Given no palette "+
+		"Then different inputs generally get different colors", func(t *testing.T) {
+		colors := make(map[string]bool)
+		for _, s := range []string{"go", "rust", "python", "java", "ruby", "elixir", "haskell", "ocaml"} {
+			colors[ColorFromString(s)] = true
+		}
+		assert.Greater(t, len(colors), 4, "expected mostly distinct colors: %v", colors)
+	})
+
+	t.Run("Given a palette "+
+		"Then a stable color of the palette is returned", func(t *testing.T) {
+		p := Palette{"#e63946", "#457b9d", "#2a9d8f"}
+
+		color := p.ColorFromString("golang")
+		assert.Contains(t, p, color, "expected a color of the palette")
+		assert.Equal(t, color, p.ColorFromString("golang"), "expected the same color for the same input")
+	})
+}
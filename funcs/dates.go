@@ -0,0 +1,21 @@
+package funcs
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateIn is the implementation of the `dateIn` template function.
+// It formats t in the named IANA time zone, rather than that of the server, eg
+//
+//	{{ dateIn "Jan 2, 2006 3:04 PM MST" .CreatedAt "America/New_York" }}
+//
+// An unknown time zone results in an error.
+func DateIn(layout string, t time.Time, tz string) (string, error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return "", fmt.Errorf("dateIn: invalid time zone %q: %w", tz, err)
+	}
+
+	return t.In(loc).Format(layout), nil
+}
@@ -0,0 +1,82 @@
+package funcs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateIn(t *testing.T) {
+	type (
+		Args struct {
+			Layout string
+			Time   time.Time
+			TZ     string
+		}
+		Expected struct {
+			Date  string
+			Error bool
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given a UTC time " +
+				"With the America/New_York time zone " +
+				"Then the time is formatted in eastern time",
+			Args: Args{
+				Layout: "2006-01-02 15:04 MST",
+				Time:   time.Date(2024, time.January, 15, 17, 30, 0, 0, time.UTC),
+				TZ:     "America/New_York",
+			},
+			Expected: Expected{
+				Date: "2024-01-15 12:30 EST",
+			},
+		},
+		{
+			Name: "Given a UTC time during daylight saving time " +
+				"With the America/New_York time zone " +
+				"Then the time is formatted in eastern daylight time",
+			Args: Args{
+				Layout: "2006-01-02 15:04 MST",
+				Time:   time.Date(2024, time.July, 1, 3, 0, 0, 0, time.UTC),
+				TZ:     "America/New_York",
+			},
+			Expected: Expected{
+				Date: "2024-06-30 23:00 EDT",
+			},
+		},
+		{
+			Name: "Given an invalid time zone " +
+				"Then an error is returned",
+			Args: Args{
+				Layout: time.RFC3339,
+				Time:   time.Date(2024, time.January, 15, 17, 30, 0, 0, time.UTC),
+				TZ:     "Mars/Olympus_Mons",
+			},
+			Expected: Expected{
+				Error: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			date, err := DateIn(test.Args.Layout, test.Args.Time, test.Args.TZ)
+
+			if !test.Expected.Error {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Date, date, "unexpected date returned")
+			} else {
+				assert.Error(t, err, "expected an error")
+			}
+		})
+	}
+}
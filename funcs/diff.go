@@ -0,0 +1,91 @@
+package funcs
+
+import (
+	"html/template"
+	"strings"
+)
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+// DiffHTML is the implementation of the `diffHTML` template function.
+// It emits the words of after, with the words removed from before wrapped in <del>, and those added wrapped in <ins>, eg
+//
+//	{{ diffHTML "the quick fox" "the slow fox" }}
+//
+// results in "the <del>quick</del> <ins>slow</ins> fox".
+// Words are separated by whitespace, and emitted separated by single spaces. Their content is escaped.
+// The diff takes time proportional to the product of the numbers of words, so suits short texts, eg changelog entries.
+func DiffHTML(before, after string) template.HTML {
+	a, b := strings.Fields(before), strings.Fields(after)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	var words []string
+	op := diffEqual
+
+	flush := func() {
+		if len(words) == 0 {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+
+		text := template.HTMLEscapeString(strings.Join(words, " "))
+		switch op {
+		case diffDelete:
+			sb.WriteString("<del>" + text + "</del>")
+		case diffInsert:
+			sb.WriteString("<ins>" + text + "</ins>")
+		default:
+			sb.WriteString(text)
+		}
+		words = words[:0]
+	}
+	emit := func(next diffOp, word string) {
+		if next != op {
+			flush()
+			op = next
+		}
+		words = append(words, word)
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			emit(diffEqual, a[i])
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			emit(diffDelete, a[i])
+			i++
+		default:
+			emit(diffInsert, b[j])
+			j++
+		}
+	}
+	flush()
+
+	return template.HTML(sb.String())
+}
@@ -0,0 +1,81 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffHTML(t *testing.T) {
+	type (
+		Args struct {
+			Old string
+			New string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected template.HTML
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given identical texts " +
+				"Then no words are wrapped",
+			Args: Args{
+				Old: "the quick fox",
+				New: "the  quick\nfox",
+			},
+			Expected: "the quick fox",
+		},
+		{
+			Name: "Given a replaced word " +
+				"Then the removed word is wrapped in del and the added word in ins",
+			Args: Args{
+				Old: "the quick fox",
+				New: "the slow fox",
+			},
+			Expected: "the <del>quick</del> <ins>slow</ins> fox",
+		},
+		{
+			Name: "Given added words " +
+				"Then they're wrapped together in ins",
+			Args: Args{
+				Old: "the fox",
+				New: "the very quick fox jumps",
+			},
+			Expected: "the <ins>very quick</ins> fox <ins>jumps</ins>",
+		},
+		{
+			Name: "Given removed words " +
+				"Then they're wrapped together in del",
+			Args: Args{
+				Old: "the very quick fox",
+				New: "fox",
+			},
+			Expected: "<del>the very quick</del> fox",
+		},
+		{
+			Name: "Given words with markup " +
+				"Then they're escaped",
+			Args: Args{
+				Old: "a <b>",
+				New: "a <i>",
+			},
+			Expected: "a <del>&lt;b&gt;</del> <ins>&lt;i&gt;</ins>",
+		},
+		{
+			Name: "Given empty texts " +
+				"Then nothing is emitted",
+			Expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, DiffHTML(test.Args.Old, test.Args.New), "unexpected diff")
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package funcs
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// The escape functions allow template authors to be explicit about the escaping context of a value,
+// where html/template's contextual auto-escaping can't infer it, eg a value built with printf.
+// Their results are typed, so html/template treats them as safe in the matching context
+// and does not escape them again. They should only be used in that context.
+
+// JSEscape is the implementation of the `jsEscape` template function.
+// It returns s as a quoted JavaScript string literal, safe for use as a value in a <script>, eg
+//
+//	<script>const name = {{ jsEscape .Name }};</script>
+func JSEscape(s string) template.JS {
+	return template.JS(`"` + template.JSEscapeString(s) + `"`)
+}
+
+// CSSEscape is the implementation of the `cssEscape` template function.
+// It escapes every character of s other than ASCII letters, digits, hyphens, and underscores
+// as a CSS escape sequence, making it safe as a CSS identifier or within a quoted CSS string, eg
+//
+//	<style>.{{ cssEscape .Class }} { color: red; }</style>
+func CSSEscape(s string) template.CSS {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == '-' && i > 0:
+			b.WriteRune(r)
+		case r >= '0' && r <= '9' && i > 0:
+			b.WriteRune(r)
+		default:
+			// the trailing space terminates the escape sequence
+			fmt.Fprintf(&b, `\%x `, r)
+		}
+	}
+	return template.CSS(b.String())
+}
+
+// attrNamePattern matches the attribute names accepted by AttrEscape.
+var attrNamePattern = regexp.MustCompile(`^[a-zA-Z_][-a-zA-Z0-9_.:]*$`)
+
+// unsafeAttrs are attributes whose values html/template would filter or escape as URLs, CSS, or HTML,
+// rather than as plain text, so can't be built by AttrEscape.
+var unsafeAttrs = map[string]bool{
+	"action":     true,
+	"background": true,
+	"cite":       true,
+	"codebase":   true,
+	"data":       true,
+	"formaction": true,
+	"href":       true,
+	"icon":       true,
+	"longdesc":   true,
+	"manifest":   true,
+	"poster":     true,
+	"src":        true,
+	"srcdoc":     true,
+	"srcset":     true,
+	"style":      true,
+	"usemap":     true,
+	"xlink:href": true,
+}
+
+// AttrEscape is the implementation of the `attrEscape` template function.
+// It takes the attribute name as well as its value, as attrEscape name value,
+// and builds a whole name="value" attribute, HTML escaping value, including quotes, for use in tag position
+// where the attribute name isn't known to the template, eg
+//
+//	<div {{ attrEscape .AttrName .Value }}>
+//
+// results in <div title="say &#34;hi&#34;">.
+// The name must be a valid attribute name, and not an event handler, eg onclick,
+// nor an attribute taking a URL, CSS, or HTML, eg href or style, as value is only escaped as text.
+// Outside of tag position, html/template escapes the result as it would any other string.
+func AttrEscape(name, value string) (template.HTMLAttr, error) {
+	if err := ValidateAttrName(name); err != nil {
+		return "", fmt.Errorf("attrEscape: %w", err)
+	}
+
+	return template.HTMLAttr(fmt.Sprintf(`%s="%s"`, name, template.HTMLEscapeString(value))), nil
+}
+
+// ValidateAttrName returns an error if name isn't a valid attribute name,
+// or is an event handler, eg onclick, or an attribute taking a URL, CSS, or HTML, eg href or style,
+// so can't be given a value escaped only as text, as by AttrEscape.
+func ValidateAttrName(name string) error {
+	if !attrNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid attribute name %q", name)
+	}
+
+	lower := strings.ToLower(name)
+	if strings.HasPrefix(lower, "on") || unsafeAttrs[lower] {
+		return fmt.Errorf("attribute %q is not allowed", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package funcs
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSEscape(t *testing.T) {
+	assert.Equal(t, template.JS(`"O\'Brien \u003C/script\u003E \"hi\""`), JSEscape(`O'Brien </script> "hi"`), "unexpected js returned")
+}
+
+func TestCSSEscape(t *testing.T) {
+	assert.Equal(t, template.CSS(`a-b_c\7b \7d \3b 1`), CSSEscape("a-b_c{};1"), "unexpected css returned")
+	assert.Equal(t, template.CSS(`\31 a`), CSSEscape("1a"), "expected a leading digit to be escaped")
+}
+
+func TestAttrEscape(t *testing.T) {
+	type (
+		Args struct {
+			Template string
+			Name     string
+			Value    string
+		}
+		Expected struct {
+			Output string
+			Error  string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given an attribute in tag position " +
+				"Then the attribute is emitted with its value escaped",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "title",
+				Value:    `say "hi" <b> & 'bye'`,
+			},
+			Expected: Expected{
+				Output: `<div title="say &#34;hi&#34; &lt;b&gt; &amp; &#39;bye&#39;">`,
+			},
+		},
+		{
+			Name: "Given an attribute within a quoted attribute value " +
+				"Then html/template escapes it as any other string",
+			Args: Args{
+				Template: `<div title="{{ attrEscape .Name .Value }}">`,
+				Name:     "title",
+				Value:    `a"b`,
+			},
+			Expected: Expected{
+				Output: `<div title="title=&#34;a&amp;#34;b&#34;">`,
+			},
+		},
+		{
+			Name: "Given a namespaced attribute name with hyphens " +
+				"Then the attribute is emitted",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "data-x_y.z:w",
+				Value:    "v",
+			},
+			Expected: Expected{
+				Output: `<div data-x_y.z:w="v">`,
+			},
+		},
+		{
+			Name: "Given an attribute name containing a value " +
+				"Then an error is returned",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "onmouseover=alert(1)",
+			},
+			Expected: Expected{
+				Error: "invalid attribute name",
+			},
+		},
+		{
+			Name: "Given an empty attribute name " +
+				"Then an error is returned",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "",
+			},
+			Expected: Expected{
+				Error: "invalid attribute name",
+			},
+		},
+		{
+			Name: "Given an attribute name containing a quote " +
+				"Then an error is returned",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "x\" onclick=\"alert(1)",
+			},
+			Expected: Expected{
+				Error: "invalid attribute name",
+			},
+		},
+		{
+			Name: "Given an attribute name containing a > " +
+				"Then an error is returned",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "x><script>alert(1)</script",
+			},
+			Expected: Expected{
+				Error: "invalid attribute name",
+			},
+		},
+		{
+			Name: "Given an attribute name containing a space " +
+				"Then an error is returned",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "data-a data-b",
+			},
+			Expected: Expected{
+				Error: "invalid attribute name",
+			},
+		},
+		{
+			Name: "Given an attribute name starting with a digit " +
+				"Then an error is returned",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "1x",
+			},
+			Expected: Expected{
+				Error: "invalid attribute name",
+			},
+		},
+		{
+			Name: "Given an event handler attribute " +
+				"Then an error is returned",
+			Args: Args{
+				Template: `<div {{ attrEscape .Name .Value }}>`,
+				Name:     "onMouseOver",
+				Value:    "alert(1)",
+			},
+			Expected: Expected{
+				Error: "is not allowed",
+			},
+		},
+		{
+			Name: "Given a url attribute " +
+				"Then an error is returned",
+			Args: Args{
+				Template: `<a {{ attrEscape .Name .Value }}>`,
+				Name:     "href",
+				Value:    "javascript:alert(1)",
+			},
+			Expected: Expected{
+				Error: "is not allowed",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(template.FuncMap{"attrEscape": AttrEscape}).Parse(test.Args.Template))
+
+			var b strings.Builder
+			err := tmpl.Execute(&b, map[string]string{"Name": test.Args.Name, "Value": test.Args.Value})
+
+			if test.Expected.Error == "" {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Output, b.String(), "unexpected output")
+			} else {
+				assert.ErrorContains(t, err, test.Expected.Error, "unexpected error returned")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package funcs
+
+import "strings"
+
+// phonePatterns are the display formats of phone numbers by region, keyed by their number of digits.
+var phonePatterns = map[string]map[int]string{
+	"US": {
+		10: "(###) ###-####",
+		11: "+# (###) ###-####",
+	},
+	"CA": {
+		10: "(###) ###-####",
+		11: "+# (###) ###-####",
+	},
+	"GB": {
+		11: "#### ### ####",
+		12: "+## #### ######",
+	},
+	"FR": {
+		10: "## ## ## ## ##",
+		11: "+## # ## ## ## ##",
+	},
+}
+
+// FormatPhone is the implementation of the `formatPhone` template function.
+// It formats the digits of the phone number s in the display format of the region, eg "US" or "GB".
+// The number is returned unchanged if the region is unknown, or it has an unexpected number of digits.
+func FormatPhone(s, region string) string {
+	patterns, ok := phonePatterns[strings.ToUpper(region)]
+	if !ok {
+		return s
+	}
+
+	digits := countDigits(s)
+
+	pattern, ok := patterns[digits]
+	if !ok {
+		return s
+	}
+	if r := strings.ToUpper(region); digits == 11 && (r == "US" || r == "CA") && firstDigit(s) != '1' {
+		return s
+	}
+
+	return FormatPattern(s, pattern)
+}
+
+// FormatPattern is the implementation of the `formatPattern` template function.
+// It formats the digits of s by replacing each # of the pattern with the next digit, eg
+//
+//	{{ formatPattern "5551234567" "(###) ###-####" }}
+//
+// results in "(555) 123-4567".
+// If s has fewer digits than the pattern, the pattern is filled up to the last digit, eg "(555) 12".
+// s is returned unchanged if it has no digits, or more digits than the pattern.
+func FormatPattern(s, pattern string) string {
+	digits := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) == 0 || len(digits) > strings.Count(pattern, "#") {
+		return s
+	}
+
+	var b strings.Builder
+	for _, r := range pattern {
+		if len(digits) == 0 {
+			break
+		}
+		if r == '#' {
+			r = digits[0]
+			digits = digits[1:]
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func countDigits(s string) int {
+	var n int
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+func firstDigit(s string) rune {
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+	}
+	return 0
+}
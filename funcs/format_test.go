@@ -0,0 +1,76 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPhone(t *testing.T) {
+	type (
+		Args struct {
+			Phone  string
+			Region string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected string
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given a US number " +
+				"Then it's formatted for the US",
+			Args: Args{
+				Phone:  "555.123.4567",
+				Region: "US",
+			},
+			Expected: "(555) 123-4567",
+		},
+		{
+			Name: "Given a US number " +
+				"With a country code " +
+				"Then it's formatted for the US",
+			Args: Args{
+				Phone:  "15551234567",
+				Region: "us",
+			},
+			Expected: "+1 (555) 123-4567",
+		},
+		{
+			Name: "Given a number " +
+				"With too few digits " +
+				"Then it's returned unchanged",
+			Args: Args{
+				Phone:  "123-45",
+				Region: "US",
+			},
+			Expected: "123-45",
+		},
+		{
+			Name: "Given a number " +
+				"With an unknown region " +
+				"Then it's returned unchanged",
+			Args: Args{
+				Phone:  "5551234567",
+				Region: "XX",
+			},
+			Expected: "5551234567",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, FormatPhone(test.Args.Phone, test.Args.Region), "unexpected phone number returned")
+		})
+	}
+}
+
+func TestFormatPattern(t *testing.T) {
+	assert.Equal(t, "(555) 123-4567", FormatPattern("5551234567", "(###) ###-####"), "unexpected format returned")
+	assert.Equal(t, "(555) 12", FormatPattern("55512", "(###) ###-####"), "expected fewer digits to partially fill the pattern")
+	assert.Equal(t, "123456", FormatPattern("123456", "##-##"), "expected too many digits to be returned unchanged")
+	assert.Equal(t, "abc", FormatPattern("abc", "##-##"), "expected no digits to be returned unchanged")
+}
@@ -0,0 +1,18 @@
+package funcs
+
+// FieldError is the implementation of the `fieldError` template function.
+// It returns the validation error message of the form field, if any, for re-rendering a form, eg
+//
+//	<input name="email" class="{{ if hasError .Errors "email" }}invalid{{ end }}">
+//	<p class="error">{{ fieldError .Errors "email" }}</p>
+//
+// errors holds the messages by field name, and may be nil.
+func FieldError(errors map[string]string, field string) string {
+	return errors[field]
+}
+
+// HasError is the implementation of the `hasError` template function.
+// It reports whether the form field has a validation error message, eg to toggle error styling.
+func HasError(errors map[string]string, field string) bool {
+	return errors[field] != ""
+}
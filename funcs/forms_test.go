@@ -0,0 +1,59 @@
+package funcs
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldError(t *testing.T) {
+	type Test struct {
+		Name     string
+		Errors   map[string]string
+		Expected string
+	}
+
+	const body = `<input name="email"{{ if hasError .Errors "email" }} class="invalid"{{ end }}>` +
+		`{{ with fieldError .Errors "email" }}<p class="error">{{ . }}</p>{{ end }}`
+
+	tests := []Test{
+		{
+			Name: "Given an error for the field " +
+				"Then it's displayed with error styling",
+			Errors: map[string]string{
+				"email": "Email is required",
+				"name":  "Name is too long",
+			},
+			Expected: `<input name="email" class="invalid"><p class="error">Email is required</p>`,
+		},
+		{
+			Name: "Given errors for other fields only " +
+				"Then the field is displayed without error styling",
+			Errors: map[string]string{
+				"name": "Name is too long",
+			},
+			Expected: `<input name="email">`,
+		},
+		{
+			Name: "Given no errors " +
+				"Then the field is displayed without error styling",
+			Expected: `<input name="email">`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpl, err := template.New("t").Funcs(DefaultMap("t", nil)).Parse(body)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+
+			var buf bytes.Buffer
+			err = tmpl.Execute(&buf, map[string]any{"Errors": test.Errors})
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+
+			assert.Equal(t, test.Expected, buf.String(), "unexpected output")
+		})
+	}
+}
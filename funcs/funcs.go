@@ -1,16 +1,70 @@
 package funcs
 
 import (
+	"context"
+	"fmt"
 	"html/template"
 	"maps"
+	"reflect"
 )
 
 type MapBuilderFunc = func(name string, props map[string]any) template.FuncMap
 
+// ContextMapBuilderFunc is a MapBuilderFunc additionally given the context of the execution.
+type ContextMapBuilderFunc = func(ctx context.Context, name string, props map[string]any) template.FuncMap
+
 func DefaultMap(name string, props map[string]any) template.FuncMap {
 	return template.FuncMap{
 		// template execution
-		"props": NewKVSProps,
+		"props":       NewKVSProps,
+		"nestedProps": NewNestedKVSProps,
+		"parseQuery":  ParseQuery,
+		"deepMerge":   DeepMerge,
+
+		// loops
+		"isFirst":   IsFirst,
+		"isLast":    IsLast,
+		"withIndex": WithIndex,
+
+		// urls
+		"urlJoin": URLJoin,
+
+		// markup
+		"table":       Table,
+		"bem":         BEM,
+		"gridClasses": GridClasses,
+		"dataAttrs":   DataAttrs,
+
+		// accessibility
+		"aria":         Aria,
+		"ariaExpanded": AriaExpanded,
+		"ariaHidden":   AriaHidden,
+		"ariaControls": AriaControls,
+
+		// forms
+		"fieldError": FieldError,
+		"hasError":   HasError,
+
+		// content
+		"tableOfContents": TableOfContents,
+		"headingIDs":      HeadingIDs,
+		"diffHTML":        DiffHTML,
+		"truncateHTML":    TruncateHTML,
+
+		// colors
+		"contrastColor": ContrastColor,
+
+		// formatting
+		"formatPhone":   FormatPhone,
+		"formatPattern": FormatPattern,
+		"plural":        Plural,
+		"ordinal":       Ordinal,
+		"dateIn":        DateIn,
+
+		// escaping
+		"jsEscape":   JSEscape,
+		"cssEscape":  CSSEscape,
+		"attrEscape": AttrEscape,
 	}
 }
 
@@ -23,3 +77,33 @@ func Chain(fns ...MapBuilderFunc) MapBuilderFunc {
 		return m
 	}
 }
+
+// Wrap decorates every function in m with wrapper, eg to log panics or time calls.
+// wrapper must return a function with the same signature as the one it's given,
+// which Decorate may be used to construct. Wrap panics otherwise, as template.Funcs would.
+func Wrap(m template.FuncMap, wrapper func(name string, fn any) any) template.FuncMap {
+	wrapped := make(template.FuncMap, len(m))
+	for name, fn := range m {
+		w := wrapper(name, fn)
+		if got, want := reflect.TypeOf(w), reflect.TypeOf(fn); got != want {
+			panic(fmt.Errorf("wrapper of func %s changed its signature from %v to %v", name, want, got))
+		}
+		wrapped[name] = w
+	}
+	return wrapped
+}
+
+// Decorate returns a function with the same signature as fn, which calls around in its place.
+// around receives the arguments and a call func, which calls fn with the given arguments.
+func Decorate(fn any, around func(args []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value) any {
+	v := reflect.ValueOf(fn)
+
+	call := v.Call
+	if v.Type().IsVariadic() {
+		call = v.CallSlice
+	}
+
+	return reflect.MakeFunc(v.Type(), func(args []reflect.Value) []reflect.Value {
+		return around(args, call)
+	}).Interface()
+}
@@ -0,0 +1,73 @@
+package funcs
+
+import (
+	"bytes"
+	"html/template"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	calls := make(map[string]int)
+
+	m := Wrap(template.FuncMap{
+		"exclaim": func(s string) string {
+			return s + "!"
+		},
+		"join": func(sep string, parts ...string) string {
+			var b bytes.Buffer
+			for i, p := range parts {
+				if i > 0 {
+					b.WriteString(sep)
+				}
+				b.WriteString(p)
+			}
+			return b.String()
+		},
+	}, func(name string, fn any) any {
+		return Decorate(fn, func(args []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value {
+			calls[name]++
+			return call(args)
+		})
+	})
+
+	tmpl, err := template.New("t").Funcs(m).Parse(`{{ exclaim "a" }} {{ join "-" "b" "c" }} {{ exclaim "d" }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+
+	assert.Equal(t, "a! b-c d!", buf.String(), "unexpected output")
+	assert.Equal(t, map[string]int{"exclaim": 2, "join": 1}, calls, "unexpected call counts")
+}
+
+func TestParseQuery(t *testing.T) {
+	props := ParseQuery("a=1&a=2&b=x")
+
+	assert.Equal(t, map[string]any{
+		"a": []string{"1", "2"},
+		"b": "x",
+	}, props, "unexpected props returned")
+}
+
+func TestNewNestedKVSProps(t *testing.T) {
+	props, err := NewNestedKVSProps("user.name", "Ann", "user.age", 30, "title", "Profile")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, map[string]any{
+		"user": map[string]any{
+			"name": "Ann",
+			"age":  30,
+		},
+		"title": "Profile",
+	}, props, "unexpected props returned")
+
+	_, err = NewNestedKVSProps("user", "Ann", "user.age", 30)
+	assert.Error(t, err, "expected a value and nested props of the same key to conflict")
+
+	_, err = NewNestedKVSProps("user.age", 30, "user", "Ann")
+	assert.Error(t, err, "expected nested props and a value of the same key to conflict")
+}
@@ -0,0 +1,121 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageConfig_Image(t *testing.T) {
+	type (
+		Args struct {
+			Config ImageConfig
+			Src    string
+			Alt    string
+			Widths []int
+		}
+		Expected struct {
+			HTML  template.HTML
+			Error bool
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given an image " +
+				"With multiple widths " +
+				"Then the srcset lists each width",
+			Args: Args{
+				Src:    "/img/cat.jpg",
+				Alt:    "A cat",
+				Widths: []int{640, 320},
+			},
+			Expected: Expected{
+				HTML: `<img src="/img/cat.jpg?w=640" srcset="/img/cat.jpg?w=320 320w, /img/cat.jpg?w=640 640w" sizes="100vw" alt="A cat">`,
+			},
+		},
+		{
+			Name: "Given an image " +
+				"With a configured url pattern and sizes " +
+				"Then the srcset urls follow the pattern",
+			Args: Args{
+				Config: ImageConfig{
+					URLPattern: "/resize/{width}{src}",
+					Sizes:      "(max-width: 600px) 100vw, 50vw",
+				},
+				Src:    "/cat.jpg",
+				Alt:    "A cat",
+				Widths: []int{100, 200},
+			},
+			Expected: Expected{
+				HTML: `<img src="/resize/200/cat.jpg" srcset="/resize/100/cat.jpg 100w, /resize/200/cat.jpg 200w" sizes="(max-width: 600px) 100vw, 50vw" alt="A cat">`,
+			},
+		},
+		{
+			Name: "Given an image " +
+				"With no widths " +
+				"Then a plain img is returned",
+			Args: Args{
+				Src: "/cat.jpg",
+				Alt: `The "cat"`,
+			},
+			Expected: Expected{
+				HTML: `<img src="/cat.jpg" alt="The &#34;cat&#34;">`,
+			},
+		},
+		{
+			Name: "Given a decorative image " +
+				"Then an empty alt is emitted",
+			Args: Args{
+				Src: "/divider.png",
+			},
+			Expected: Expected{
+				HTML: `<img src="/divider.png" alt="">`,
+			},
+		},
+		{
+			Name: "Given an image " +
+				"With a src of a disallowed scheme " +
+				"Then an error is returned",
+			Args: Args{
+				Src: "javascript:alert(1)",
+				Alt: "A cat",
+			},
+			Expected: Expected{
+				Error: true,
+			},
+		},
+		{
+			Name: "Given an image " +
+				"With a non-positive width " +
+				"Then an error is returned",
+			Args: Args{
+				Src:    "/cat.jpg",
+				Widths: []int{0},
+			},
+			Expected: Expected{
+				Error: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			h, err := test.Args.Config.Image(test.Args.Src, test.Args.Alt, test.Args.Widths...)
+
+			if !test.Expected.Error {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.HTML, h, "unexpected html returned")
+			} else {
+				assert.Error(t, err, "expected an error")
+			}
+		})
+	}
+}
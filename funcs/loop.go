@@ -0,0 +1,57 @@
+package funcs
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// LoopItem is an element of the slice given to WithIndex, along with its position.
+type LoopItem struct {
+	Index int
+	Value any
+	First bool
+	Last  bool
+}
+
+// IsFirst is the implementation of the `isFirst` template function.
+// It reports whether the index of a range is that of the first element, eg
+//
+//	{{ range $i, $item := .Items }}{{ if not (isFirst $i) }}, {{ end }}{{ $item }}{{ end }}
+func IsFirst(index int) bool {
+	return index == 0
+}
+
+// IsLast is the implementation of the `isLast` template function.
+// It reports whether the index of a range is that of the last of length elements, eg
+//
+//	{{ range $i, $item := .Items }}{{ $item }}{{ if not (isLast $i (len $.Items)) }}, {{ end }}{{ end }}
+func IsLast(index, length int) bool {
+	return index == length-1
+}
+
+// WithIndex is the implementation of the `withIndex` template function.
+// It returns the elements of the slice or array, each with its index, and whether it's first or last, eg
+//
+//	{{ range withIndex .Items }}<li{{ if .Last }} class="last"{{ end }}>{{ .Value }}</li>{{ end }}
+func WithIndex(slice any) ([]LoopItem, error) {
+	v := reflect.ValueOf(slice)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+	case reflect.Invalid:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("withIndex expected a slice or array: received a %T", slice)
+	}
+
+	items := make([]LoopItem, v.Len())
+	for i := range items {
+		items[i] = LoopItem{
+			Index: i,
+			Value: v.Index(i).Interface(),
+			First: i == 0,
+			Last:  i == len(items)-1,
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,89 @@
+package funcs
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoops(t *testing.T) {
+	type (
+		Args struct {
+			Body  string
+			Items any
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected string
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given isLast " +
+				"Then no separator follows the last item",
+			Args: Args{
+				Body:  `{{ range $i, $item := .Items }}{{ $item }}{{ if not (isLast $i (len $.Items)) }}, {{ end }}{{ end }}`,
+				Items: []string{"a", "b", "c"},
+			},
+			Expected: "a, b, c",
+		},
+		{
+			Name: "Given isFirst " +
+				"Then no separator precedes the first item",
+			Args: Args{
+				Body:  `{{ range $i, $item := .Items }}{{ if not (isFirst $i) }} | {{ end }}{{ $item }}{{ end }}`,
+				Items: []string{"a", "b", "c"},
+			},
+			Expected: "a | b | c",
+		},
+		{
+			Name: "Given withIndex " +
+				"Then each item has its index and position",
+			Args: Args{
+				Body:  `{{ range withIndex .Items }}<li{{ if .First }} class="first"{{ else if .Last }} class="last"{{ end }}>{{ .Index }}:{{ .Value }}</li>{{ end }}`,
+				Items: [3]int{7, 8, 9},
+			},
+			Expected: `<li class="first">0:7</li><li>1:8</li><li class="last">2:9</li>`,
+		},
+		{
+			Name: "Given withIndex of a single item " +
+				"Then it's both first and last",
+			Args: Args{
+				Body:  `{{ range withIndex .Items }}{{ .First }} {{ .Last }}{{ end }}`,
+				Items: []string{"a"},
+			},
+			Expected: "true true",
+		},
+		{
+			Name: "Given withIndex of nil " +
+				"Then nothing is ranged over",
+			Args: Args{
+				Body: `{{ range withIndex .Items }}{{ .Value }}{{ else }}none{{ end }}`,
+			},
+			Expected: "none",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpl, err := template.New("t").Funcs(DefaultMap("t", nil)).Parse(test.Args.Body)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+
+			var buf bytes.Buffer
+			err = tmpl.Execute(&buf, map[string]any{"Items": test.Args.Items})
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+
+			assert.Equal(t, test.Expected, buf.String(), "unexpected output")
+		})
+	}
+}
+
+func TestWithIndex_NotASlice(t *testing.T) {
+	_, err := WithIndex(7)
+	assert.Error(t, err, "expected an error")
+}
@@ -0,0 +1,84 @@
+package funcs
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// The slice behaviors of DeepMerge.
+const (
+	// MergeReplace replaces the slices of a with those of b.
+	MergeReplace = "replace"
+	// MergeAppend appends the slices of b to those of a.
+	MergeAppend = "append"
+)
+
+// DeepMerge is the implementation of the `deepMerge` template function.
+// It returns a copy of a with the entries of b merged into it, recursively merging nested maps, eg
+//
+//	{{ component "card" (deepMerge "append" .Defaults .Overrides) }}
+//
+// slices is MergeReplace or MergeAppend, determining whether the slices of b replace or are appended to those of a.
+// Otherwise, the values of b replace those of a, including maps replacing scalars and scalars replacing maps.
+// Only maps of type map[string]any are merged. Neither a nor b is modified.
+func DeepMerge(slices string, a, b map[string]any) (map[string]any, error) {
+	if slices != MergeReplace && slices != MergeAppend {
+		return nil, fmt.Errorf("deepMerge expected the slice behavior to be %q or %q: received %q", MergeReplace, MergeAppend, slices)
+	}
+
+	return deepMerge(slices, a, b), nil
+}
+
+func deepMerge(slices string, a, b map[string]any) map[string]any {
+	merged := make(map[string]any, len(a)+len(b))
+	maps.Copy(merged, a)
+
+	for k, bv := range b {
+		av, ok := merged[k]
+		if !ok {
+			merged[k] = bv
+			continue
+		}
+
+		am, aIsMap := av.(map[string]any)
+		bm, bIsMap := bv.(map[string]any)
+		if aIsMap && bIsMap {
+			merged[k] = deepMerge(slices, am, bm)
+			continue
+		}
+
+		if slices == MergeAppend {
+			if s, ok := appendSlices(av, bv); ok {
+				merged[k] = s
+				continue
+			}
+		}
+
+		merged[k] = bv
+	}
+
+	return merged
+}
+
+// appendSlices appends the slice b to the slice a, reporting false if either isn't a slice.
+// Slices of the same type result in a slice of that type, and a []any otherwise.
+func appendSlices(a, b any) (any, bool) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() != reflect.Slice || bv.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	if av.Type() == bv.Type() {
+		s := reflect.MakeSlice(av.Type(), 0, av.Len()+bv.Len())
+		return reflect.AppendSlice(reflect.AppendSlice(s, av), bv).Interface(), true
+	}
+
+	s := make([]any, 0, av.Len()+bv.Len())
+	for _, v := range []reflect.Value{av, bv} {
+		for i := range v.Len() {
+			s = append(s, v.Index(i).Interface())
+		}
+	}
+	return s, true
+}
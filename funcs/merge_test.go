@@ -0,0 +1,118 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepMerge(t *testing.T) {
+	type (
+		Args struct {
+			Slices string
+			A      map[string]any
+			B      map[string]any
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected map[string]any
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given nested maps " +
+				"Then they're merged recursively",
+			Args: Args{
+				Slices: MergeReplace,
+				A: map[string]any{
+					"title": "A",
+					"theme": map[string]any{
+						"color": "red",
+						"font":  map[string]any{"size": 12, "family": "serif"},
+					},
+				},
+				B: map[string]any{
+					"theme": map[string]any{
+						"font": map[string]any{"size": 14},
+					},
+					"footer": true,
+				},
+			},
+			Expected: map[string]any{
+				"title": "A",
+				"theme": map[string]any{
+					"color": "red",
+					"font":  map[string]any{"size": 14, "family": "serif"},
+				},
+				"footer": true,
+			},
+		},
+		{
+			Name: "Given slices to replace " +
+				"Then the slices of b replace those of a",
+			Args: Args{
+				Slices: MergeReplace,
+				A:      map[string]any{"classes": []string{"card"}},
+				B:      map[string]any{"classes": []string{"wide"}},
+			},
+			Expected: map[string]any{"classes": []string{"wide"}},
+		},
+		{
+			Name: "Given slices to append " +
+				"Then the slices of b are appended to those of a",
+			Args: Args{
+				Slices: MergeAppend,
+				A:      map[string]any{"nested": map[string]any{"classes": []string{"card"}}},
+				B:      map[string]any{"nested": map[string]any{"classes": []string{"wide"}}},
+			},
+			Expected: map[string]any{"nested": map[string]any{"classes": []string{"card", "wide"}}},
+		},
+		{
+			Name: "Given slices of different types to append " +
+				"Then a slice of any is returned",
+			Args: Args{
+				Slices: MergeAppend,
+				A:      map[string]any{"values": []string{"a"}},
+				B:      map[string]any{"values": []int{1}},
+			},
+			Expected: map[string]any{"values": []any{"a", 1}},
+		},
+		{
+			Name: "Given a scalar and a map " +
+				"Then the value of b wins",
+			Args: Args{
+				Slices: MergeAppend,
+				A:      map[string]any{"x": "scalar", "y": map[string]any{"z": 1}},
+				B:      map[string]any{"x": map[string]any{"z": 2}, "y": "scalar"},
+			},
+			Expected: map[string]any{"x": map[string]any{"z": 2}, "y": "scalar"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			merged, err := DeepMerge(test.Args.Slices, test.Args.A, test.Args.B)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+			assert.Equal(t, test.Expected, merged, "unexpected merge result")
+		})
+	}
+}
+
+func TestDeepMerge_DoesNotModifyInputs(t *testing.T) {
+	a := map[string]any{"nested": map[string]any{"x": 1}}
+	b := map[string]any{"nested": map[string]any{"y": 2}}
+
+	_, err := DeepMerge(MergeReplace, a, b)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, map[string]any{"nested": map[string]any{"x": 1}}, a, "unexpected modification of a")
+	assert.Equal(t, map[string]any{"nested": map[string]any{"y": 2}}, b, "unexpected modification of b")
+}
+
+func TestDeepMerge_UnknownSliceBehavior(t *testing.T) {
+	_, err := DeepMerge("concat", nil, nil)
+	assert.Error(t, err, "expected an error")
+}
@@ -0,0 +1,27 @@
+package funcs
+
+import "strconv"
+
+// Ordinal is the implementation of the `ordinal` template function.
+// It returns n with its English ordinal suffix, eg 1st, 2nd, 3rd, 4th, 11th, 12th, 13th, 21st, and 111th.
+// Negative numbers take the suffix of their magnitude, eg -2nd.
+func Ordinal(n int) string {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+
+	suffix := "th"
+	if abs%100 < 11 || abs%100 > 13 {
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+
+	return strconv.Itoa(n) + suffix
+}
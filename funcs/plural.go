@@ -0,0 +1,74 @@
+package funcs
+
+import "strings"
+
+// Plural is the implementation of the `plural` template function.
+// It returns singular when count is 1 or -1, otherwise plural, eg
+//
+//	{{ .Count }} {{ plural .Count "item" "items" }}
+func Plural(count int, singular, plural string) string {
+	if count == 1 || count == -1 {
+		return singular
+	}
+	return plural
+}
+
+// Pluralizer pluralizes English words.
+type Pluralizer struct {
+	// Plurals is a catalog of plural forms by singular form, taking precedence over the English rules,
+	// eg for irregular or non-English words.
+	Plurals map[string]string
+}
+
+// Pluralize is the implementation of the `pluralize` template function, using the default Pluralizer.
+func Pluralize(count int, word string) string {
+	return Pluralizer{}.Pluralize(count, word)
+}
+
+// Pluralize returns word when count is 1 or -1, otherwise its plural form,
+// from the catalog if present, otherwise by simple English pluralization rules.
+func (p Pluralizer) Pluralize(count int, word string) string {
+	if count == 1 || count == -1 || word == "" {
+		return word
+	}
+
+	if plural, ok := p.Plurals[word]; ok {
+		return plural
+	}
+	if plural, ok := irregularPlurals[strings.ToLower(word)]; ok {
+		return matchCase(word, plural)
+	}
+
+	lower := strings.ToLower(word)
+	switch {
+	case strings.HasSuffix(lower, "s"),
+		strings.HasSuffix(lower, "x"),
+		strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"),
+		strings.HasSuffix(lower, "sh"):
+		return word + matchCase(word[len(word)-1:], "es")
+	case len(lower) > 1 && lower[len(lower)-1] == 'y' && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return word[:len(word)-1] + matchCase(word[len(word)-1:], "ies")
+	default:
+		return word + matchCase(word[len(word)-1:], "s")
+	}
+}
+
+var irregularPlurals = map[string]string{
+	"child":  "children",
+	"foot":   "feet",
+	"goose":  "geese",
+	"man":    "men",
+	"mouse":  "mice",
+	"person": "people",
+	"tooth":  "teeth",
+	"woman":  "women",
+}
+
+// matchCase returns s in upper case if ref is entirely upper case, otherwise s unchanged.
+func matchCase(ref, s string) string {
+	if ref == strings.ToUpper(ref) && ref != strings.ToLower(ref) {
+		return strings.ToUpper(s)
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlural(t *testing.T) {
+	assert.Equal(t, "items", Plural(0, "item", "items"), "unexpected word for 0")
+	assert.Equal(t, "item", Plural(1, "item", "items"), "unexpected word for 1")
+	assert.Equal(t, "items", Plural(2, "item", "items"), "unexpected word for 2")
+}
+
+func TestPluralizer_Pluralize(t *testing.T) {
+	type (
+		Args struct {
+			Plurals map[string]string
+			Count   int
+			Word    string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected string
+		}
+	)
+
+	tests := []Test{
+		{
+			Name:     "Given a count of 0 Then the plural is returned",
+			Args:     Args{Count: 0, Word: "item"},
+			Expected: "items",
+		},
+		{
+			Name:     "Given a count of 1 Then the word is returned",
+			Args:     Args{Count: 1, Word: "item"},
+			Expected: "item",
+		},
+		{
+			Name:     "Given a count of 2 Then the plural is returned",
+			Args:     Args{Count: 2, Word: "item"},
+			Expected: "items",
+		},
+		{
+			Name:     "Given a word ending in a sibilant Then es is appended",
+			Args:     Args{Count: 2, Word: "box"},
+			Expected: "boxes",
+		},
+		{
+			Name:     "Given a word ending in a consonant and y Then ies replaces the y",
+			Args:     Args{Count: 2, Word: "city"},
+			Expected: "cities",
+		},
+		{
+			Name:     "Given a word ending in a vowel and y Then s is appended",
+			Args:     Args{Count: 2, Word: "day"},
+			Expected: "days",
+		},
+		{
+			Name:     "Given a known irregular word Then its irregular plural is returned",
+			Args:     Args{Count: 2, Word: "child"},
+			Expected: "children",
+		},
+		{
+			Name: "Given an irregular word " +
+				"With its plural in the catalog " +
+				"Then the catalog plural is returned",
+			Args: Args{
+				Plurals: map[string]string{"cactus": "cacti"},
+				Count:   3,
+				Word:    "cactus",
+			},
+			Expected: "cacti",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			p := Pluralizer{Plurals: test.Args.Plurals}
+			assert.Equal(t, test.Expected, p.Pluralize(test.Args.Count, test.Args.Word), "unexpected word returned")
+		})
+	}
+}
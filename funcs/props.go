@@ -1,6 +1,10 @@
 package funcs
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
 
 // NewKVSProps is the implementation of the `props` template function.
 func NewKVSProps(args ...any) (map[string]any, error) {
@@ -20,3 +24,72 @@ func NewKVSProps(args ...any) (map[string]any, error) {
 
 	return props, nil
 }
+
+// NewNestedKVSProps is the implementation of the `nestedProps` template function.
+// It's NewKVSProps, except keys are split on dots into nested props, eg
+//
+//	{{ nestedProps "user.name" "Ann" "user.age" 30 }}
+//
+// results in map[string]any{"user": map[string]any{"name": "Ann", "age": 30}}.
+// A key used both for a value and for nested props results in an error.
+func NewNestedKVSProps(args ...any) (map[string]any, error) {
+	flat, err := NewKVSProps(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	props := make(map[string]any, len(flat))
+	for i := 0; i < len(args); i += 2 {
+		key := args[i].(string)
+		if err := setNestedProp(props, key, flat[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return props, nil
+}
+
+func setNestedProp(props map[string]any, key string, value any) error {
+	parts := strings.Split(key, ".")
+
+	m := props
+	for i, part := range parts[:len(parts)-1] {
+		switch v := m[part].(type) {
+		case nil:
+			sub := make(map[string]any)
+			m[part] = sub
+			m = sub
+		case map[string]any:
+			m = v
+		default:
+			return fmt.Errorf("nestedProps key %s conflicts with the value of key %s", key, strings.Join(parts[:i+1], "."))
+		}
+	}
+
+	last := parts[len(parts)-1]
+	if _, isMap := m[last].(map[string]any); isMap {
+		return fmt.Errorf("nestedProps key %s conflicts with nested keys of the same prefix", key)
+	}
+	m[last] = value
+
+	return nil
+}
+
+// ParseQuery is the implementation of the `parseQuery` template function.
+// It parses a raw URL query string into props.
+// Keys appearing once map to a string, while repeated keys map to a []string.
+// Malformed pairs are skipped.
+func ParseQuery(raw string) map[string]any {
+	values, _ := url.ParseQuery(strings.TrimPrefix(raw, "?"))
+
+	props := make(map[string]any, len(values))
+	for k, vs := range values {
+		if len(vs) == 1 {
+			props[k] = vs[0]
+		} else {
+			props[k] = vs
+		}
+	}
+
+	return props
+}
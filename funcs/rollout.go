@@ -0,0 +1,25 @@
+package funcs
+
+import "hash/fnv"
+
+// InRollout reports whether key, eg a user id, falls within the given percentage, 0 to 100, of the flag's rollout.
+// The result is deterministic, so a key remains in or out of a rollout for as long as its percentage is unchanged,
+// and keys in a rollout remain in it as its percentage increases.
+func InRollout(percentage float64, flag, key string) bool {
+	if percentage <= 0 {
+		return false
+	}
+	if percentage >= 100 {
+		return true
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(flag))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+
+	// buckets of a hundredth of a percent
+	bucket := h.Sum64() % 10000
+
+	return float64(bucket) < percentage*100
+}
@@ -0,0 +1,27 @@
+package funcs
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInRollout(t *testing.T) {
+	for i := range 100 {
+		key := strconv.Itoa(i)
+		assert.Equal(t, InRollout(10, "flag", key), InRollout(10, "flag", key), "expected the same key to yield the same result")
+	}
+
+	var enabled int
+	const keys = 10000
+	for i := range keys {
+		if InRollout(10, "flag", "user-"+strconv.Itoa(i)) {
+			enabled++
+		}
+	}
+	assert.InDelta(t, 0.10, float64(enabled)/keys, 0.02, "expected roughly 10%% of keys to be enabled")
+
+	assert.False(t, InRollout(0, "flag", "user"), "expected a 0%% rollout to be disabled")
+	assert.True(t, InRollout(100, "flag", "user"), "expected a 100%% rollout to be enabled")
+}
@@ -0,0 +1,54 @@
+package funcs
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// SortQuery is the query string of the current request, whose parameters sort links preserve.
+type SortQuery string
+
+// SortHeader emits a sortable table header link, as SortQuery.SortHeader does, preserving no other parameters.
+func SortHeader(label, field, currentSort, currentDir string) template.HTML {
+	return SortQuery("").SortHeader(label, field, currentSort, currentDir)
+}
+
+// SortHeader is the implementation of the `sortHeader` template function.
+// It emits a link sorting by the field, eg
+//
+//	<th>{{ sortHeader "Name" "name" .sort .dir }}</th>
+//
+// If the table is currently sorted by the field, the link reverses the direction, and the label is followed
+// by an arrow indicating the current direction. Otherwise the link sorts in ascending order.
+// The sort and dir parameters are set in the link's query, and any other parameters of q are preserved.
+func (q SortQuery) SortHeader(label, field, currentSort, currentDir string) template.HTML {
+	values, _ := url.ParseQuery(strings.TrimPrefix(string(q), "?"))
+
+	active := field == currentSort
+	desc := strings.EqualFold(currentDir, "desc")
+
+	dir := "asc"
+	if active && !desc {
+		dir = "desc"
+	}
+
+	values.Set("sort", field)
+	values.Set("dir", dir)
+
+	var b strings.Builder
+	b.WriteString(`<a href="?`)
+	b.WriteString(template.HTMLEscapeString(values.Encode()))
+	b.WriteString(`">`)
+	b.WriteString(template.HTMLEscapeString(label))
+	if active {
+		if desc {
+			b.WriteString(" ▼")
+		} else {
+			b.WriteString(" ▲")
+		}
+	}
+	b.WriteString("</a>")
+
+	return template.HTML(b.String())
+}
@@ -0,0 +1,80 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortQuery_SortHeader(t *testing.T) {
+	type (
+		Args struct {
+			Query       SortQuery
+			Label       string
+			Field       string
+			CurrentSort string
+			CurrentDir  string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected template.HTML
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given an inactive column " +
+				"Then the link sorts ascending without an arrow",
+			Args: Args{
+				Label:       "Name",
+				Field:       "name",
+				CurrentSort: "age",
+				CurrentDir:  "asc",
+			},
+			Expected: `<a href="?dir=asc&amp;sort=name">Name</a>`,
+		},
+		{
+			Name: "Given the active column sorted ascending " +
+				"Then the link sorts descending with an up arrow",
+			Args: Args{
+				Label:       "Name",
+				Field:       "name",
+				CurrentSort: "name",
+				CurrentDir:  "asc",
+			},
+			Expected: `<a href="?dir=desc&amp;sort=name">Name ▲</a>`,
+		},
+		{
+			Name: "Given the active column sorted descending " +
+				"Then the link sorts ascending with a down arrow",
+			Args: Args{
+				Label:       "Name",
+				Field:       "name",
+				CurrentSort: "name",
+				CurrentDir:  "desc",
+			},
+			Expected: `<a href="?dir=asc&amp;sort=name">Name ▼</a>`,
+		},
+		{
+			Name: "Given a query with other parameters " +
+				"Then they're preserved, and the sort parameters replaced",
+			Args: Args{
+				Query:       "?q=cats&sort=age&dir=asc",
+				Label:       "Name & Title",
+				Field:       "name",
+				CurrentSort: "age",
+				CurrentDir:  "asc",
+			},
+			Expected: `<a href="?dir=asc&amp;q=cats&amp;sort=name">Name &amp; Title</a>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := test.Args.Query.SortHeader(test.Args.Label, test.Args.Field, test.Args.CurrentSort, test.Args.CurrentDir)
+			assert.Equal(t, test.Expected, got, "unexpected link returned")
+		})
+	}
+}
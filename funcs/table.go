@@ -0,0 +1,104 @@
+package funcs
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+)
+
+// Table is the implementation of the `table` template function.
+// rows must be a slice or array of structs or maps.
+// columns are label-accessor pairs, in the same manner as the `props` function,
+// the labels being the column headers, and the accessors the struct field names or map keys of each cell.
+// Example:
+//
+//	{{ table .Users "Name" "FullName" "Email" "Email" }}
+func Table(rows any, columns ...any) (template.HTML, error) {
+	if len(columns)%2 == 1 {
+		return "", fmt.Errorf("the table function expects an even number of column arguments, label-accessor pairs: received %d arguments", len(columns))
+	}
+
+	labels := make([]string, len(columns)/2)
+	accessors := make([]string, len(columns)/2)
+	for i := 0; i < len(columns); i += 2 {
+		label, ok := columns[i].(string)
+		if !ok {
+			return "", fmt.Errorf("table expected column labels to be strings: argument %d was a %T", i+2, columns[i])
+		}
+		accessor, ok := columns[i+1].(string)
+		if !ok {
+			return "", fmt.Errorf("table expected column accessors to be strings: argument %d was a %T", i+3, columns[i+1])
+		}
+
+		labels[i/2] = label
+		accessors[i/2] = accessor
+	}
+
+	v := reflect.ValueOf(rows)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+	case reflect.Invalid:
+		v = reflect.ValueOf([]any{})
+	default:
+		return "", fmt.Errorf("table expected rows to be a slice or array: received a %T", rows)
+	}
+
+	var b strings.Builder
+
+	b.WriteString("<table><thead><tr>")
+	for _, label := range labels {
+		b.WriteString("<th>")
+		b.WriteString(template.HTMLEscapeString(label))
+		b.WriteString("</th>")
+	}
+	b.WriteString("</tr></thead><tbody>")
+
+	for i := range v.Len() {
+		b.WriteString("<tr>")
+		for _, accessor := range accessors {
+			cell, err := tableCell(v.Index(i), accessor)
+			if err != nil {
+				return "", fmt.Errorf("table row %d: %w", i, err)
+			}
+
+			b.WriteString("<td>")
+			b.WriteString(template.HTMLEscapeString(cell))
+			b.WriteString("</td>")
+		}
+		b.WriteString("</tr>")
+	}
+
+	b.WriteString("</tbody></table>")
+
+	return template.HTML(b.String()), nil
+}
+
+func tableCell(row reflect.Value, accessor string) (string, error) {
+	for row.Kind() == reflect.Pointer || row.Kind() == reflect.Interface {
+		if row.IsNil() {
+			return "", nil
+		}
+		row = row.Elem()
+	}
+
+	switch row.Kind() {
+	case reflect.Struct:
+		f, ok := row.Type().FieldByName(accessor)
+		if !ok || !f.IsExported() {
+			return "", fmt.Errorf("no exported field %s in %s", accessor, row.Type())
+		}
+		return fmt.Sprint(row.FieldByIndex(f.Index).Interface()), nil
+	case reflect.Map:
+		if row.Type().Key().Kind() != reflect.String {
+			return "", fmt.Errorf("map keys must be strings: received %s", row.Type())
+		}
+		cell := row.MapIndex(reflect.ValueOf(accessor).Convert(row.Type().Key()))
+		if !cell.IsValid() {
+			return "", nil
+		}
+		return fmt.Sprint(cell.Interface()), nil
+	default:
+		return "", fmt.Errorf("expected a struct or map: received %s", row.Type())
+	}
+}
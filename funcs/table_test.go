@@ -0,0 +1,45 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable(t *testing.T) {
+	type Pet struct {
+		Name    string
+		Species string
+	}
+
+	h, err := Table([]Pet{
+		{Name: "Rex", Species: "dog"},
+		{Name: "<Tom>", Species: "cat"},
+	}, "Pet Name", "Name", "Species", "Species")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, template.HTML(`<table><thead><tr><th>Pet Name</th><th>Species</th></tr></thead><tbody>`+
+		`<tr><td>Rex</td><td>dog</td></tr>`+
+		`<tr><td>&lt;Tom&gt;</td><td>cat</td></tr>`+
+		`</tbody></table>`), h, "unexpected html returned")
+}
+
+func TestTable_Maps(t *testing.T) {
+	h, err := Table([]map[string]any{
+		{"a": 1},
+		{"a": 2, "b": "x"},
+	}, "A", "a", "B", "b")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, template.HTML(`<table><thead><tr><th>A</th><th>B</th></tr></thead><tbody>`+
+		`<tr><td>1</td><td></td></tr>`+
+		`<tr><td>2</td><td>x</td></tr>`+
+		`</tbody></table>`), h, "unexpected html returned")
+}
+
+func TestTable_UnknownField(t *testing.T) {
+	_, err := Table([]struct{ A int }{{A: 1}}, "B", "B")
+	assert.Error(t, err, "expected an error")
+}
@@ -0,0 +1,65 @@
+package funcs
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// DefaultWordsPerMinute is the reading speed assumed by ReadingTime.
+const DefaultWordsPerMinute = 200
+
+// ReadingTime is the implementation of the `readingTime` template function, using the default reading speed.
+func ReadingTime(content any) int {
+	return ReadingSpeed(DefaultWordsPerMinute).ReadingTime(content)
+}
+
+// ReadingSpeed is a reading speed in words per minute.
+type ReadingSpeed int
+
+// ReadingTime estimates the minutes needed to read content, rounded up.
+// content may be a string or template.HTML; any HTML tags, scripts, and styles are not counted.
+func (wpm ReadingSpeed) ReadingTime(content any) int {
+	if wpm <= 0 {
+		wpm = DefaultWordsPerMinute
+	}
+
+	words := len(strings.Fields(TextContent(fmt.Sprint(content))))
+
+	return (words + int(wpm) - 1) / int(wpm)
+}
+
+// TextContent returns the text of the HTML s, excluding tags, comments, scripts, and styles.
+// Entities are unescaped.
+func TextContent(s string) string {
+	var b strings.Builder
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	var skip atom.Atom
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return b.String()
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if a := atom.Lookup(name); skip == 0 && (a == atom.Script || a == atom.Style) {
+				skip = a
+			}
+			b.WriteByte(' ')
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			if atom.Lookup(name) == skip {
+				skip = 0
+			}
+			b.WriteByte(' ')
+		case html.SelfClosingTagToken:
+			b.WriteByte(' ')
+		case html.TextToken:
+			if skip == 0 {
+				b.Write(z.Text())
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package funcs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadingTime(t *testing.T) {
+	words := strings.Repeat("word ", 450)
+
+	assert.Equal(t, 3, ReadingTime(words), "expected 450 words to take 3 minutes at 200 words per minute")
+	assert.Equal(t, 2, ReadingSpeed(300).ReadingTime(words), "expected 450 words to take 2 minutes at 300 words per minute")
+	assert.Equal(t, 0, ReadingTime(""), "expected no words to take no time")
+}
+
+func TestReadingTime_HTML(t *testing.T) {
+	content := "<p>one <b>two</b></p><script>not counted at all</script><p>three&nbsp;four</p>"
+
+	assert.Equal(t, 1, ReadingSpeed(4).ReadingTime(content), "expected 4 words to take 1 minute at 4 words per minute")
+	assert.Equal(t, 2, ReadingSpeed(3).ReadingTime(content), "expected 4 words to take 2 minutes at 3 words per minute")
+}
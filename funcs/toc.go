@@ -0,0 +1,193 @@
+package funcs
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// TableOfContents is the implementation of the `tableOfContents` template function.
+// It returns a nested <ul> of links to the <h2> and <h3> headings of the HTML content, eg
+//
+//	<nav>{{ tableOfContents .Content }}</nav>
+//	<article>{{ headingIDs .Content }}</article>
+//
+// Headings without ids are linked by the ids HeadingIDs assigns them.
+func TableOfContents(content template.HTML) template.HTML {
+	type entry struct {
+		heading  contentHeading
+		children []contentHeading
+	}
+
+	var entries []entry
+	for _, h := range contentHeadings(string(content)) {
+		if h.level == 3 && len(entries) > 0 {
+			last := &entries[len(entries)-1]
+			last.children = append(last.children, h)
+		} else {
+			entries = append(entries, entry{heading: h})
+		}
+	}
+
+	var b strings.Builder
+
+	b.WriteString("<ul>")
+	for _, e := range entries {
+		b.WriteString("<li>")
+		writeTOCLink(&b, e.heading)
+		if len(e.children) > 0 {
+			b.WriteString("<ul>")
+			for _, h := range e.children {
+				b.WriteString("<li>")
+				writeTOCLink(&b, h)
+				b.WriteString("</li>")
+			}
+			b.WriteString("</ul>")
+		}
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ul>")
+
+	return template.HTML(b.String())
+}
+
+func writeTOCLink(b *strings.Builder, h contentHeading) {
+	b.WriteString(`<a href="#`)
+	b.WriteString(template.HTMLEscapeString(h.id))
+	b.WriteString(`">`)
+	b.WriteString(template.HTMLEscapeString(h.text))
+	b.WriteString("</a>")
+}
+
+// HeadingIDs is the implementation of the `headingIDs` template function.
+// It returns the HTML content with an id assigned to each <h2> and <h3> heading without one,
+// derived from its text, eg "Getting Started" becomes "getting-started".
+// Repeated ids are suffixed with their count, eg "usage-2".
+func HeadingIDs(content template.HTML) template.HTML {
+	headings := make(map[int]contentHeading)
+	for _, h := range contentHeadings(string(content)) {
+		headings[h.token] = h
+	}
+
+	var b strings.Builder
+
+	z := html.NewTokenizer(strings.NewReader(string(content)))
+	for token := 0; ; token++ {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return template.HTML(b.String())
+		}
+
+		raw := string(z.Raw())
+
+		// the headings are matched by the index of their start tag token, keeping both walks of the content in step
+		h, ok := headings[token]
+		if !ok || h.hasID {
+			b.WriteString(raw)
+			continue
+		}
+
+		t := z.Token()
+		t.Attr = append(t.Attr, html.Attribute{Key: "id", Val: h.id})
+		b.WriteString(t.String())
+	}
+}
+
+type contentHeading struct {
+	// token is the index of the start tag token of the heading within the content.
+	token int
+	level int
+	text  string
+	id    string
+	hasID bool
+}
+
+// contentHeadings returns the <h2> and <h3> headings of the HTML content, in order,
+// with ids assigned to those without.
+// Headings nested within another, and self-closing headings, eg <h2/>, are ignored.
+func contentHeadings(s string) []contentHeading {
+	var headings []contentHeading
+	used := make(map[string]bool)
+
+	var current *contentHeading
+	var text strings.Builder
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for token := 0; ; token++ {
+		switch tt := z.Next(); tt {
+		case html.ErrorToken:
+			return assignHeadingIDs(headings, used)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			for _, a := range t.Attr {
+				if a.Key == "id" {
+					used[a.Val] = true
+				}
+			}
+
+			if tt == html.StartTagToken && current == nil && (t.DataAtom == atom.H2 || t.DataAtom == atom.H3) {
+				headings = append(headings, contentHeading{token: token, level: 2})
+				current = &headings[len(headings)-1]
+				if t.DataAtom == atom.H3 {
+					current.level = 3
+				}
+				for _, a := range t.Attr {
+					if a.Key == "id" {
+						current.id, current.hasID = a.Val, true
+					}
+				}
+				text.Reset()
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); current != nil && (atom.Lookup(name) == atom.H2 || atom.Lookup(name) == atom.H3) {
+				current.text = strings.Join(strings.Fields(text.String()), " ")
+				current = nil
+			}
+		case html.TextToken:
+			if current != nil {
+				text.Write(z.Text())
+			}
+		}
+	}
+}
+
+func assignHeadingIDs(headings []contentHeading, used map[string]bool) []contentHeading {
+	for i := range headings {
+		h := &headings[i]
+		if h.hasID {
+			continue
+		}
+
+		base := slug(h.text)
+		if base == "" {
+			base = "section"
+		}
+
+		h.id = base
+		for n := 2; used[h.id]; n++ {
+			h.id = base + "-" + strconv.Itoa(n)
+		}
+		used[h.id] = true
+	}
+
+	return headings
+}
+
+// slug returns the lower case letters and digits of s, separated by hyphens, eg "Getting Started!" becomes "getting-started".
+func slug(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+			b.WriteByte('-')
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
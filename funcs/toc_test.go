@@ -0,0 +1,90 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableOfContents(t *testing.T) {
+	type (
+		Expected struct {
+			TOC     template.HTML
+			Content template.HTML
+		}
+		Test struct {
+			Name     string
+			Content  template.HTML
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given h2 and h3 headings " +
+				"Then the h3 headings are nested under the preceding h2 heading",
+			Content: `<h2>Getting Started</h2><p>a</p><h3>Install</h3><h3>Configure</h3><h2>Usage</h2>`,
+			Expected: Expected{
+				TOC: `<ul><li><a href="#getting-started">Getting Started</a>` +
+					`<ul><li><a href="#install">Install</a></li><li><a href="#configure">Configure</a></li></ul></li>` +
+					`<li><a href="#usage">Usage</a></li></ul>`,
+				Content: `<h2 id="getting-started">Getting Started</h2><p>a</p><h3 id="install">Install</h3>` +
+					`<h3 id="configure">Configure</h3><h2 id="usage">Usage</h2>`,
+			},
+		},
+		{
+			Name: "Given headings with the same text " +
+				"Then their ids are suffixed",
+			Content: `<h2>Usage</h2><h3>Example</h3><h2>Usage</h2><h3>Example</h3>`,
+			Expected: Expected{
+				TOC: `<ul><li><a href="#usage">Usage</a><ul><li><a href="#example">Example</a></li></ul></li>` +
+					`<li><a href="#usage-2">Usage</a><ul><li><a href="#example-2">Example</a></li></ul></li></ul>`,
+				Content: `<h2 id="usage">Usage</h2><h3 id="example">Example</h3><h2 id="usage-2">Usage</h2><h3 id="example-2">Example</h3>`,
+			},
+		},
+		{
+			Name: "Given a heading with an id " +
+				"Then its id is kept, and not reused",
+			Content: `<h2 id="intro" class="title">Intro <em>to</em> &amp; more</h2><p id="faq"></p><h2>FAQ</h2>`,
+			Expected: Expected{
+				TOC:     `<ul><li><a href="#intro">Intro to &amp; more</a></li><li><a href="#faq-2">FAQ</a></li></ul>`,
+				Content: `<h2 id="intro" class="title">Intro <em>to</em> &amp; more</h2><p id="faq"></p><h2 id="faq-2">FAQ</h2>`,
+			},
+		},
+		{
+			Name: "Given a heading nested within another " +
+				"Then only the outer heading is listed",
+			Content: `<h2>A<h3>B</h3></h2><h2>C</h2>`,
+			Expected: Expected{
+				TOC:     `<ul><li><a href="#ab">AB</a></li><li><a href="#c">C</a></li></ul>`,
+				Content: `<h2 id="ab">A<h3>B</h3></h2><h2 id="c">C</h2>`,
+			},
+		},
+		{
+			Name: "Given a self-closing heading " +
+				"Then it's ignored, and the ids of later headings are unaffected",
+			Content: `<h2/><p>a</p><h2>Usage</h2><h3>Example</h3>`,
+			Expected: Expected{
+				TOC:     `<ul><li><a href="#usage">Usage</a><ul><li><a href="#example">Example</a></li></ul></li></ul>`,
+				Content: `<h2/><p>a</p><h2 id="usage">Usage</h2><h3 id="example">Example</h3>`,
+			},
+		},
+		{
+			Name: "Given no headings " +
+				"Then an empty list is returned",
+			Content: `<p>text</p>`,
+			Expected: Expected{
+				TOC:     `<ul></ul>`,
+				Content: `<p>text</p>`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected.TOC, TableOfContents(test.Content), "unexpected table of contents returned")
+			assert.Equal(t, test.Expected.Content, HeadingIDs(test.Content), "unexpected content returned")
+		})
+	}
+}
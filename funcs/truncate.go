@@ -0,0 +1,101 @@
+package funcs
+
+import (
+	"html/template"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// truncationMarker is appended to the text of HTML truncated by TruncateHTML.
+const truncationMarker = "…"
+
+// voidElements are the elements without end tags, which TruncateHTML doesn't close.
+var voidElements = map[atom.Atom]bool{
+	atom.Area:   true,
+	atom.Base:   true,
+	atom.Br:     true,
+	atom.Col:    true,
+	atom.Embed:  true,
+	atom.Hr:     true,
+	atom.Img:    true,
+	atom.Input:  true,
+	atom.Link:   true,
+	atom.Meta:   true,
+	atom.Source: true,
+	atom.Track:  true,
+	atom.Wbr:    true,
+}
+
+// TruncateHTML is the implementation of the `truncateHTML` template function.
+// It truncates the HTML content to its first n characters of text, appending an ellipsis and closing any elements left open, eg
+//
+//	{{ truncateHTML "<p>Hello <b>world</b> friends</p>" 8 }}
+//
+// results in "<p>Hello <b>wo…</b></p>". Tags, and the text of scripts and styles, aren't counted.
+// Content no longer than n characters is returned as is. For previews, follow it with a read-more link, eg
+//
+//	{{ truncateHTML .Post.Body 200 }} <a href="{{ .Post.URL }}">Read more</a>
+func TruncateHTML(content template.HTML, n int) template.HTML {
+	if n < 0 {
+		n = 0
+	}
+
+	var b strings.Builder
+	var open []string
+	var skip atom.Atom
+
+	z := html.NewTokenizer(strings.NewReader(string(content)))
+	for {
+		tt := z.Next()
+		// copied before Text unescapes the text in place
+		raw := string(z.Raw())
+
+		switch tt {
+		case html.ErrorToken:
+			return content
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			a := atom.Lookup(name)
+			if !voidElements[a] {
+				open = append(open, string(name))
+			}
+			if skip == 0 && (a == atom.Script || a == atom.Style) {
+				skip = a
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			a := atom.Lookup(name)
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == string(name) {
+					open = open[:i]
+					break
+				}
+			}
+			if a == skip {
+				skip = 0
+			}
+		case html.TextToken:
+			if skip != 0 {
+				break
+			}
+
+			text := []rune(string(z.Text()))
+			if len(text) <= n {
+				n -= len(text)
+				break
+			}
+
+			b.WriteString(html.EscapeString(strings.TrimRight(string(text[:n]), " \t\r\n")))
+			b.WriteString(truncationMarker)
+			for i := len(open) - 1; i >= 0; i-- {
+				b.WriteString("</" + open[i] + ">")
+			}
+
+			return template.HTML(b.String())
+		}
+
+		b.WriteString(raw)
+	}
+}
@@ -0,0 +1,76 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateHTML(t *testing.T) {
+	type (
+		Args struct {
+			Content template.HTML
+			N       int
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected template.HTML
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given content truncated within a nested element " +
+				"Then every open element is closed",
+			Args: Args{
+				Content: "<p>Hello <b>world</b> friends</p>",
+				N:       8,
+			},
+			Expected: "<p>Hello <b>wo…</b></p>",
+		},
+		{
+			Name: "Given content truncated after a nested element " +
+				"Then only the elements still open are closed",
+			Args: Args{
+				Content: "<p>Hello <b>world</b> friends</p>",
+				N:       14,
+			},
+			Expected: "<p>Hello <b>world</b> fr…</p>",
+		},
+		{
+			Name: "Given content truncated at a space " +
+				"Then the trailing space is trimmed",
+			Args: Args{
+				Content: "<p>Hello world</p>",
+				N:       6,
+			},
+			Expected: "<p>Hello…</p>",
+		},
+		{
+			Name: "Given content no longer than n " +
+				"Then it's returned as is",
+			Args: Args{
+				Content: "<p>Hello <b>world</b></p>",
+				N:       11,
+			},
+			Expected: "<p>Hello <b>world</b></p>",
+		},
+		{
+			Name: "Given void elements and entities " +
+				"Then the elements aren't closed and the entities count as single characters",
+			Args: Args{
+				Content: "<p>a &amp; b<br>c <img src=\"x.png\">d e</p>",
+				N:       8,
+			},
+			Expected: "<p>a &amp; b<br>c <img src=\"x.png\">d…</p>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, TruncateHTML(test.Args.Content, test.Args.N), "unexpected output")
+		})
+	}
+}
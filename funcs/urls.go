@@ -0,0 +1,101 @@
+package funcs
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// URLPolicy describes which URLs are accepted by SafeURLStrict.
+// The zero value permits http, https, mailto, and relative URLs.
+type URLPolicy struct {
+	// AllowData additionally permits data: URLs, eg for inline images.
+	AllowData bool
+}
+
+// SafeURLStrict is the implementation of the `safeURLStrict` template function,
+// using the default URLPolicy.
+func SafeURLStrict(s string) (template.URL, error) {
+	return URLPolicy{}.SafeURLStrict(s)
+}
+
+// SafeURLStrict validates s against the policy and returns it as a template.URL.
+// Unlike the escaping html/template applies to URLs, any URL with a disallowed
+// scheme, eg javascript:, results in an error rather than a sanitized value.
+func (p URLPolicy) SafeURLStrict(s string) (template.URL, error) {
+	trimmed := strings.TrimSpace(s)
+
+	u, err := url.Parse(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("safeURLStrict: invalid url %q: %w", s, err)
+	}
+
+	switch scheme := strings.ToLower(u.Scheme); scheme {
+	case "":
+		// relative urls, eg /path, path, //host/path, ?query, #fragment
+	case "http", "https":
+		if u.Host == "" {
+			return "", fmt.Errorf("safeURLStrict: %s url %q has no host", scheme, s)
+		}
+	case "mailto":
+	case "data":
+		if !p.AllowData {
+			return "", fmt.Errorf("safeURLStrict: data urls are not allowed: %q", s)
+		}
+	default:
+		return "", fmt.Errorf("safeURLStrict: url scheme %q is not allowed: %q", scheme, s)
+	}
+
+	return template.URL(u.String()), nil
+}
+
+// URLJoin is the implementation of the `urlJoin` template function.
+// It joins the path segments onto base with single slashes, eg
+//
+//	{{ urlJoin "/api/" "/pets/" "123" }}
+//
+// results in "/api/pets/123".
+// Leading slashes of base, and any query or fragment of base, are preserved, as is a trailing slash
+// of the last segment. Empty segments are skipped.
+// A segment may itself contain slashes, eg "api/v1", which are kept as path separators.
+// Each part of a segment between slashes is path escaped, so a segment containing ? or #, eg user input,
+// can't alter the query or fragment, and dot parts, "." and "..", result in an error,
+// so a segment can't alter the path before it.
+func URLJoin(base string, segments ...string) (string, error) {
+	var suffix string
+	if i := strings.IndexAny(base, "?#"); i >= 0 {
+		base, suffix = base[:i], base[i:]
+	}
+
+	joined := base
+	trailingSlash := strings.HasSuffix(base, "/")
+	for _, seg := range segments {
+		var parts []string
+		for _, part := range strings.Split(seg, "/") {
+			if part == "" {
+				continue
+			}
+			if part == "." || part == ".." {
+				return "", fmt.Errorf("urlJoin: dot segment %q is not allowed in %q", part, seg)
+			}
+			parts = append(parts, url.PathEscape(part))
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		escaped := strings.Join(parts, "/")
+
+		joined = strings.TrimRight(joined, "/") + "/" + escaped
+		if joined == "/"+escaped && base == "" {
+			joined = escaped
+		}
+		trailingSlash = strings.HasSuffix(seg, "/")
+	}
+
+	if trailingSlash && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+
+	return joined + suffix, nil
+}
@@ -0,0 +1,246 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLPolicy_SafeURLStrict(t *testing.T) {
+	type (
+		Args struct {
+			Policy URLPolicy
+			URL    string
+		}
+		Expected struct {
+			URL   template.URL
+			Error bool
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given an https url " +
+				"Then the url is returned",
+			Args: Args{
+				URL: "https://example.com/a?b=c",
+			},
+			Expected: Expected{
+				URL: "https://example.com/a?b=c",
+			},
+		},
+		{
+			Name: "Given a relative url " +
+				"Then the url is returned",
+			Args: Args{
+				URL: "/pets/123",
+			},
+			Expected: Expected{
+				URL: "/pets/123",
+			},
+		},
+		{
+			Name: "Given a mailto url " +
+				"Then the url is returned",
+			Args: Args{
+				URL: "mailto:someone@example.com",
+			},
+			Expected: Expected{
+				URL: "mailto:someone@example.com",
+			},
+		},
+		{
+			Name: "Given a javascript url " +
+				"Then an error is returned",
+			Args: Args{
+				URL: "javascript:alert(1)",
+			},
+			Expected: Expected{
+				Error: true,
+			},
+		},
+		{
+			Name: "Given a mixed case javascript url " +
+				"Then an error is returned",
+			Args: Args{
+				URL: " JavaScript:alert(1)",
+			},
+			Expected: Expected{
+				Error: true,
+			},
+		},
+		{
+			Name: "Given a data url " +
+				"And data urls are not allowed " +
+				"Then an error is returned",
+			Args: Args{
+				URL: "data:image/png;base64,AAAA",
+			},
+			Expected: Expected{
+				Error: true,
+			},
+		},
+		{
+			Name: "Given a data url " +
+				"And data urls are allowed " +
+				"Then the url is returned",
+			Args: Args{
+				Policy: URLPolicy{AllowData: true},
+				URL:    "data:image/png;base64,AAAA",
+			},
+			Expected: Expected{
+				URL: "data:image/png;base64,AAAA",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			u, err := test.Args.Policy.SafeURLStrict(test.Args.URL)
+
+			if !test.Expected.Error {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.URL, u, "unexpected url returned")
+			} else {
+				assert.Error(t, err, "expected an error")
+			}
+		})
+	}
+}
+
+func TestURLJoin(t *testing.T) {
+	type (
+		Args struct {
+			Base     string
+			Segments []string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected string
+			Error    bool
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given segments without slashes " +
+				"Then they're joined with single slashes",
+			Args: Args{
+				Base:     "/api",
+				Segments: []string{"pets", "123"},
+			},
+			Expected: "/api/pets/123",
+		},
+		{
+			Name: "Given segments with leading and trailing slashes " +
+				"Then they're joined with single slashes",
+			Args: Args{
+				Base:     "/api/",
+				Segments: []string{"/pets/", "/123"},
+			},
+			Expected: "/api/pets/123",
+		},
+		{
+			Name: "Given an empty segment " +
+				"Then it's skipped",
+			Args: Args{
+				Base:     "/api",
+				Segments: []string{"", "pets", "/"},
+			},
+			Expected: "/api/pets",
+		},
+		{
+			Name: "Given a base with a query and fragment " +
+				"Then they're preserved",
+			Args: Args{
+				Base:     "https://example.com/api/?page=2#top",
+				Segments: []string{"pets"},
+			},
+			Expected: "https://example.com/api/pets?page=2#top",
+		},
+		{
+			Name: "Given a relative base " +
+				"Then no leading slash is added",
+			Args: Args{
+				Base:     "",
+				Segments: []string{"pets", "123/"},
+			},
+			Expected: "pets/123/",
+		},
+		{
+			Name: "Given segments containing a query or a fragment " +
+				"Then they're escaped within their segment",
+			Args: Args{
+				Base:     "/api?v=1",
+				Segments: []string{"x?role=admin", "y#top"},
+			},
+			Expected: "/api/x%3Frole=admin/y%23top?v=1",
+		},
+		{
+			Name: "Given a segment containing slashes " +
+				"Then they're kept as path separators",
+			Args: Args{
+				Base:     "https://x",
+				Segments: []string{"api/v1", "pets//a b"},
+			},
+			Expected: "https://x/api/v1/pets/a%20b",
+		},
+		{
+			Name: "Given a segment containing a dot segment " +
+				"Then an error is returned",
+			Args: Args{
+				Base:     "/api",
+				Segments: []string{"a/../admin"},
+			},
+			Error: true,
+		},
+		{
+			Name: "Given a dot segment " +
+				"Then an error is returned",
+			Args: Args{
+				Base:     "/api/pets",
+				Segments: []string{".."},
+			},
+			Error: true,
+		},
+		{
+			Name: "Given a dot segment with slashes " +
+				"Then an error is returned",
+			Args: Args{
+				Base:     "/api/pets",
+				Segments: []string{"/./"},
+			},
+			Error: true,
+		},
+		{
+			Name: "Given the root base " +
+				"Then the leading slash is preserved",
+			Args: Args{
+				Base:     "/",
+				Segments: []string{"pets"},
+			},
+			Expected: "/pets",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			u, err := URLJoin(test.Args.Base, test.Args.Segments...)
+
+			if !test.Error {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected, u, "unexpected url returned")
+			} else {
+				assert.Error(t, err, "expected an error")
+			}
+		})
+	}
+}
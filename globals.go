@@ -0,0 +1,26 @@
+package templater
+
+import (
+	"context"
+	"fmt"
+)
+
+// addGlobalProps adds the props computed by Config.GlobalPropsProvider, if set, to those not already provided.
+func (tm *Templater) addGlobalProps(ctx context.Context, props map[string]any) error {
+	if tm.cfg.GlobalPropsProvider == nil {
+		return nil
+	}
+
+	globals, err := tm.cfg.GlobalPropsProvider(ctx)
+	if err != nil {
+		return fmt.Errorf("global props provider failed: %w", err)
+	}
+
+	for k, v := range globals {
+		if _, ok := props[k]; !ok {
+			props[k] = v
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package templater
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecutePageContext(t *testing.T) {
+	type userKey struct{}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		GlobalPropsProvider: func(ctx context.Context) (map[string]any, error) {
+			user, ok := ctx.Value(userKey{}).(string)
+			if !ok {
+				return nil, errors.New("no user")
+			}
+			return map[string]any{"User": user}, nil
+		},
+	})
+
+	ctx := context.WithValue(context.Background(), userKey{}, "ada")
+
+	t.Run("Given a global props provider "+
+		"Then its props are provided to the page", func(t *testing.T) {
+		b, err := tm.ExecutePageContext(ctx, "user_page")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Contains(t, string(b), "<p>Signed in as ada</p>", "unexpected output")
+	})
+
+	t.Run("Given a prop provided at execution "+
+		"Then it takes precedence over the global props", func(t *testing.T) {
+		b, err := tm.ExecutePageContext(ctx, "user_page", "User", "grace")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Contains(t, string(b), "<p>Signed in as grace</p>", "unexpected output")
+	})
+
+	t.Run("Given the global props provider failing "+
+		"Then the execution is aborted", func(t *testing.T) {
+		_, err := tm.ExecutePageContext(context.Background(), "user_page")
+		assert.ErrorContains(t, err, "no user", "unexpected error returned")
+	})
+}
@@ -0,0 +1,100 @@
+package templater
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+)
+
+const (
+	// headItemsKey is the key the items of the `headItem` template function are stored under.
+	headItemsKey = "headItems"
+	// headItemsPlaceholder is emitted by the `headItems` template function while executing a layout,
+	// to be replaced by the items once the whole page has been executed.
+	headItemsPlaceholder = "<!--templater-head-items-->"
+)
+
+type headItem struct {
+	priority int
+	content  template.HTML
+}
+
+// headItem is the implementation of the `headItem` template function.
+// It stores the content for the `headItems` template function to emit in order of priority,
+// eg {{ headItem 30 `<script src="/app.js" defer></script>` }}, and outputs nothing.
+// Lower priorities are emitted first, conventionally 0 for meta tags, 10 for preloads,
+// 20 for stylesheets, and 30 for scripts.
+// Items stored anywhere within a page are emitted by the layout, as described by headItems.
+// The content isn't escaped, so must not contain user input.
+func (rs *renderState) headItem(priority int, content any) (string, error) {
+	var html template.HTML
+	switch c := content.(type) {
+	case template.HTML:
+		html = c
+	case string:
+		html = template.HTML(c)
+	default:
+		return "", fmt.Errorf("headItem expected the content to be a string: received a %T", content)
+	}
+
+	return rs.storeValue(headItemsKey, headItem{priority: priority, content: html}), nil
+}
+
+// headItems is the implementation of the `headItems` template function.
+// It emits the content stored by headItem, ordered by priority, then the order stored.
+// Repeated content is emitted once, at the lowest of its priorities.
+// Within a layout, eg in its <head>, the items are emitted once the whole page has been executed,
+// so include those stored by the page body and its components, which are executed after the <head>.
+// Otherwise, eg when streaming a page via ExecutePageTo, only the items stored so far are emitted,
+// unless Config.TwoPass is set.
+func (rs *renderState) headItems() template.HTML {
+	if rs.deferHeadItems {
+		return headItemsPlaceholder
+	}
+	return rs.formatHeadItems(rs.retrieve(headItemsKey))
+}
+
+// executeLayout executes the layout, deferring the items emitted by the `headItems` template function
+// until the whole page has been executed.
+func (rs *renderState) executeLayout(w *bytes.Buffer, layout *template.Template, props map[string]any) error {
+	rs.deferHeadItems = true
+	if err := layout.Execute(w, props); err != nil {
+		return err
+	}
+
+	b := bytes.ReplaceAll(w.Bytes(), []byte(headItemsPlaceholder), []byte(rs.formatHeadItems(rs.store[headItemsKey])))
+	w.Reset()
+	w.Write(b)
+
+	return nil
+}
+
+func (rs *renderState) formatHeadItems(stored []any) template.HTML {
+	var items []headItem
+	for _, v := range stored {
+		item := v.(headItem)
+
+		i := slices.IndexFunc(items, func(existing headItem) bool {
+			return existing.content == item.content
+		})
+		if i < 0 {
+			items = append(items, item)
+		} else if item.priority < items[i].priority {
+			items[i].priority = item.priority
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b headItem) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString(string(item.content))
+	}
+
+	return template.HTML(b.String())
+}
@@ -0,0 +1,55 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_HeadItems(t *testing.T) {
+	const layout = `<head>{{ block "head" . }}{{ end }}{{ headItems }}</head><body>{{ block "body" . }}{{ end }}</body>`
+
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	}
+
+	b, err := new(Templater).With(cfg).ExecuteInlinePage(
+		layout,
+		`{{ headItem 30 "<script src=\"/app.js\"></script>" }}`+
+			`{{ headItem 20 "<link rel=\"stylesheet\" href=\"/app.css\">" }}`+
+			`{{ headItem 0 "<meta charset=\"utf-8\">" }}`+
+			`{{ headItem 40 "<script src=\"/app.js\"></script>" }}`,
+		`<p>body</p>`,
+	)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<head><meta charset="utf-8"><link rel="stylesheet" href="/app.css"><script src="/app.js"></script></head><body><p>body</p></body>`,
+		string(b), "unexpected bytes returned")
+
+	b, err = new(Templater).With(cfg).ExecuteInlinePage(
+		layout,
+		`{{ headItem 30 "<script src=\"/app.js\"></script>" }}`,
+		`{{ headItem 0 "<meta charset=\"utf-8\">" }}<p>body</p>`,
+	)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<head><meta charset="utf-8"><script src="/app.js"></script></head><body><p>body</p></body>`,
+		string(b), "expected the items stored by the body to be emitted in a single pass")
+
+	cfg.TwoPass = true
+
+	b, err = new(Templater).With(cfg).ExecuteInlinePage(
+		layout,
+		`{{ headItem 30 "<script src=\"/app.js\"></script>" }}`,
+		`{{ headItem 0 "<meta charset=\"utf-8\">" }}<p>body</p>`,
+	)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<head><meta charset="utf-8"><script src="/app.js"></script></head><body><p>body</p></body>`,
+		string(b), "expected the items stored by the body to be emitted in a two pass execution")
+}
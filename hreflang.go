@@ -0,0 +1,46 @@
+package templater
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// hreflangsPriority is the `headItem` priority of the links emitted by the `hreflangs` template function.
+const hreflangsPriority = 0
+
+// hreflangs is the implementation of the `hreflangs` template function.
+// It stores a <link rel="alternate"> for the path in each of Config.Locales as a head item,
+// for the `headItems` template function to emit, eg
+//
+//	{{ define "head" }}{{ hreflangs "/pricing" }}{{ end }}
+//
+// for the locales en and de links /en/pricing with hreflang en, /de/pricing with hreflang de,
+// and /en/pricing again with hreflang x-default, the default locale being the first.
+// URLs are constructed by Config.LocaleURLPattern. Without locales, nothing is stored.
+// As with `headItem`, the links are emitted once however many times they're stored.
+func (ec *executionContext) hreflangs(path string) (string, error) {
+	if len(ec.cfg.Locales) == 0 {
+		return "", nil
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	var b strings.Builder
+	link := func(hreflang, locale string) {
+		url := strings.NewReplacer("{locale}", locale, "{path}", path).Replace(ec.cfg.LocaleURLPattern)
+		fmt.Fprintf(&b, `<link rel="alternate" hreflang="%s" href="%s">`,
+			template.HTMLEscapeString(hreflang),
+			template.HTMLEscapeString(url),
+		)
+	}
+
+	for _, locale := range ec.cfg.Locales {
+		link(locale, locale)
+	}
+	link("x-default", ec.cfg.Locales[0])
+
+	return ec.render.headItem(hreflangsPriority, b.String())
+}
@@ -0,0 +1,79 @@
+package templater
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_Hreflangs(t *testing.T) {
+	type (
+		Args struct {
+			Locales          []string
+			LocaleURLPattern string
+			Head             string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected string
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given locales " +
+				"Then a link is emitted once for each locale, and the default",
+			Args: Args{
+				Locales: []string{"en", "de", "pt-BR"},
+				Head:    `{{ hreflangs "/pricing" }}{{ hreflangs "/pricing" }}`,
+			},
+			Expected: `<link rel="alternate" hreflang="en" href="/en/pricing">` +
+				`<link rel="alternate" hreflang="de" href="/de/pricing">` +
+				`<link rel="alternate" hreflang="pt-BR" href="/pt-BR/pricing">` +
+				`<link rel="alternate" hreflang="x-default" href="/en/pricing">`,
+		},
+		{
+			Name: "Given a locale url pattern " +
+				"Then the links are constructed by it",
+			Args: Args{
+				Locales:          []string{"en", "de"},
+				LocaleURLPattern: "https://{locale}.example.com{path}",
+				Head:             `{{ hreflangs "pricing" }}`,
+			},
+			Expected: `<link rel="alternate" hreflang="en" href="https://en.example.com/pricing">` +
+				`<link rel="alternate" hreflang="de" href="https://de.example.com/pricing">` +
+				`<link rel="alternate" hreflang="x-default" href="https://en.example.com/pricing">`,
+		},
+		{
+			Name: "Given no locales " +
+				"Then nothing is emitted",
+			Args: Args{
+				Head: `{{ hreflangs "/pricing" }}`,
+			},
+			Expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tm := new(Templater).With(Config{
+				Dirs: DirsConfig{
+					Base:       "test_dir/test_templates",
+					Pages:      "test_pages",
+					Components: "test_components",
+				},
+				Locales:          test.Args.Locales,
+				LocaleURLPattern: test.Args.LocaleURLPattern,
+			})
+
+			b, err := tm.ExecuteInlinePage(`<head>{{ block "head" . }}{{ end }}{{ headItems }}</head>`, test.Args.Head, "")
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+
+			head := strings.TrimSuffix(strings.TrimPrefix(string(b), "<head>"), "</head>")
+			assert.Equal(t, test.Expected, head, "unexpected head")
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package templater
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// RenderToResponse executes the template of the given name, as Execute would,
+// writing the output as an HTML response with an accurate Content-Length.
+// HEAD requests receive the headers alone.
+// The request's headers are provided to the `header` template function, as by WithHeaders.
+// Nothing is written if execution fails, leaving the error response to the caller.
+func (tm *Templater) RenderToResponse(w http.ResponseWriter, r *http.Request, name string, kvs ...any) error {
+	b, err := tm.WithHeaders(r.Header).Execute(name, kvs...)
+	if err != nil {
+		return err
+	}
+
+	h := w.Header()
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", "text/html; charset="+tm.cfg.contentCharset())
+	}
+	h.Set("Content-Length", strconv.Itoa(len(b)))
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method == http.MethodHead {
+		return nil
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// WithHeaders returns a copy of the Templater which provides the request headers to the `header` template function
+// of every execution.
+func (tm *Templater) WithHeaders(h http.Header) *Templater {
+	cpy := *tm
+	cpy.headers = h
+	return &cpy
+}
+
+// header is the implementation of the `header` template function.
+// It returns the first value of the request header, if listed by Config.AllowedHeaders, or empty otherwise.
+func (ec *executionContext) header(name string) string {
+	name = http.CanonicalHeaderKey(name)
+
+	if !slices.ContainsFunc(ec.cfg.AllowedHeaders, func(allowed string) bool {
+		return http.CanonicalHeaderKey(allowed) == name
+	}) {
+		return ""
+	}
+
+	return ec.headers.Get(name)
+}
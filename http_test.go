@@ -0,0 +1,64 @@
+package templater
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_RenderToResponse(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	w := httptest.NewRecorder()
+	require.NoError(t, tm.RenderToResponse(w, httptest.NewRequest(http.MethodGet, "/simple_page", nil), "simple_page"))
+
+	assert.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"), "unexpected content type")
+	assert.Contains(t, w.Body.String(), "TEST", "unexpected body")
+	assert.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"), "expected the content length to match the body")
+
+	contentLength := w.Header().Get("Content-Length")
+
+	w = httptest.NewRecorder()
+	require.NoError(t, tm.RenderToResponse(w, httptest.NewRequest(http.MethodHead, "/simple_page", nil), "simple_page"))
+
+	assert.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+	assert.Equal(t, contentLength, w.Header().Get("Content-Length"), "expected the content length of the HEAD request to match the GET request")
+	assert.Zero(t, w.Body.Len(), "expected no body for a HEAD request")
+}
+
+func TestTemplater_WithHeaders(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		AllowedHeaders: []string{"accept-language", "X-Tenant"},
+	})
+
+	h := http.Header{}
+	h.Set("Accept-Language", "en-GB")
+	h.Set("X-Tenant", "acme")
+	h.Set("Cookie", "session=secret")
+
+	const body = `<p>{{ header "Accept-Language" }}</p><p>{{ header "x-tenant" }}</p><p>{{ header "Cookie" }}</p><p>{{ header "X-Missing" }}</p>`
+
+	b, err := tm.WithHeaders(h).ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<p>en-GB</p><p>acme</p><p></p><p></p>`, string(b), "unexpected bytes returned")
+
+	b, err = tm.ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<p></p><p></p><p></p><p></p>`, string(b), "expected no headers without WithHeaders")
+}
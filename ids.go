@@ -0,0 +1,67 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+
+	"golang.org/x/net/html"
+)
+
+// uniqueID is the implementation of the `uniqueID` template function.
+// It returns the next id of the prefix in the execution, eg field-1, field-2, and so on,
+// so that ids are unique within a page, yet the same for every execution of it.
+func (rs *renderState) uniqueID(prefix string) string {
+	rs.ids[prefix]++
+	return formatID(prefix, rs.ids[prefix])
+}
+
+// refID is the implementation of the `refID` template function.
+// It returns the id last generated by uniqueID for the prefix, eg to reference an input from its label:
+//
+//	<input id="{{ uniqueID "field" }}">
+//	<label for="{{ refID "field" }}">Name</label>
+func (rs *renderState) refID(prefix string) (string, error) {
+	n, ok := rs.ids[prefix]
+	if !ok {
+		return "", fmt.Errorf("refID: no id has been generated with the prefix %q", prefix)
+	}
+	return formatID(prefix, n), nil
+}
+
+func formatID(prefix string, n int) string {
+	return fmt.Sprintf("%s-%d", prefix, n)
+}
+
+// checkDuplicateIDs returns an ErrDuplicateIDs if the output uses an id more than once.
+func checkDuplicateIDs(b []byte) error {
+	if ids := duplicateIDs(b); len(ids) > 0 {
+		return &ErrDuplicateIDs{
+			IDs: ids,
+		}
+	}
+	return nil
+}
+
+// duplicateIDs returns the values of the id attributes used more than once in the html, in order of first use.
+func duplicateIDs(doc []byte) []string {
+	var duplicates []string
+	counts := make(map[string]int)
+
+	z := html.NewTokenizer(bytes.NewReader(doc))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return duplicates
+		case html.StartTagToken, html.SelfClosingTagToken:
+			id := attr(z.Token(), "id")
+			if id == "" {
+				continue
+			}
+
+			counts[id]++
+			if counts[id] == 2 {
+				duplicates = append(duplicates, id)
+			}
+		}
+	}
+}
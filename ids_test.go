@@ -0,0 +1,65 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_UniqueID(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	const body = `<input id="{{ uniqueID "field" }}"><label for="{{ refID "field" }}"></label>` +
+		`<input id="{{ uniqueID "field" }}"><label for="{{ refID "field" }}"></label>` +
+		`<p id="{{ uniqueID "note" }}"></p>`
+
+	expected := `<input id="field-1"><label for="field-1"></label>` +
+		`<input id="field-2"><label for="field-2"></label>` +
+		`<p id="note-1"></p>`
+
+	b, err := tm.ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, expected, string(b), "unexpected bytes returned")
+
+	b, err = tm.ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, expected, string(b), "expected ids to be scoped to a single execution")
+
+	_, err = tm.ExecuteInline(`{{ refID "field" }}`)
+	assert.Error(t, err, "expected an error referencing an id never generated")
+}
+
+func TestTemplater_CheckDuplicateIDs(t *testing.T) {
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	}
+
+	const body = `<form id="form">{{ component "submit_button" "Label" "Save" }}{{ component "submit_button" "Label" "Cancel" }}</form>`
+
+	_, err := new(Templater).With(cfg).ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	cfg.CheckDuplicateIDs = true
+	tm := new(Templater).With(cfg)
+
+	_, err = tm.ExecuteInline(body)
+
+	var dupErr *ErrDuplicateIDs
+	require.ErrorAs(t, err, &dupErr, "expected an ErrDuplicateIDs")
+	assert.Equal(t, []string{"submit"}, dupErr.IDs, "unexpected duplicate ids reported")
+
+	b, err := tm.ExecuteComponent("submit_button", "Label", "Save")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<button id="submit">Save</button>`+"\n", string(b), "unexpected bytes returned")
+}
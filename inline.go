@@ -0,0 +1,87 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+const inlineTemplateName = "inline"
+
+// ExecuteInline executes the given template source as if it were a component.
+// It has access to the same template functions, so may use any component on disk.
+// It's useful for testing components without fixture files.
+func (tm *Templater) ExecuteInline(body string, kvs ...any) ([]byte, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm.finishOutput(tm.newContext().executeInline(body, props))
+}
+
+// ExecuteInlinePage executes the given layout template source, defining the "head" and "body"
+// templates with the given sources, just as ExecutePage would with files on disk.
+// An empty head leaves the "head" template undefined.
+func (tm *Templater) ExecuteInlinePage(layout, head, body string, kvs ...any) ([]byte, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		return ec.executeInlinePage(layout, head, body, props)
+	}))
+}
+
+func (ec *executionContext) executeInline(body string, props map[string]any) ([]byte, error) {
+	t, err := template.New(inlineTemplateName).
+		Funcs(ec.buildFuncMap(inlineTemplateName, props)).
+		Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inline template: %w", err)
+	}
+
+	if ec.template, err = t.Clone(); err != nil {
+		return nil, fmt.Errorf("failed to create template clone: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, props); err != nil {
+		return nil, fmt.Errorf("failed to execute inline template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (ec *executionContext) executeInlinePage(layoutSource, head, body string, props map[string]any) ([]byte, error) {
+	ec.isPage = true
+
+	layout, err := template.New("layout").
+		Funcs(ec.buildFuncMap(inlineTemplateName, props)).
+		Parse(layoutSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inline layout template: %w", err)
+	}
+
+	if head != "" {
+		if _, err := layout.New("head").Parse(head); err != nil {
+			return nil, fmt.Errorf("failed to parse inline head template: %w", err)
+		}
+	}
+
+	if _, err := layout.New("body").Parse(body); err != nil {
+		return nil, fmt.Errorf("failed to parse inline body template: %w", err)
+	}
+
+	if ec.template, err = layout.Clone(); err != nil {
+		return nil, fmt.Errorf("failed to clone layout template for component execution: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := ec.render.executeLayout(buf, layout, props); err != nil {
+		return nil, fmt.Errorf("failed to execute html template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
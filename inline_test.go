@@ -0,0 +1,69 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yosssi/gohtml"
+)
+
+func TestTemplater_ExecuteInline(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInline(`<section>{{ component "component_1" "X" .X "Y" 123 "Z" true }}</section>`, "X", "inline")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<section>
+  <div>
+    <div>
+      inline
+    </div>
+    <div>
+      123
+    </div>
+    <div>
+      true
+    </div>
+  </div>
+</section>`, gohtml.Format(string(b)), "unexpected bytes returned")
+}
+
+func TestTemplater_ExecuteInlinePage(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInlinePage(
+		`<html><head>{{ block "head" . }}{{ end }}</head><body>{{ block "body" . }}{{ end }}</body></html>`,
+		`<title>{{ .Title }}</title>`,
+		`<main>{{ component "embedded" }}</main>`,
+		"Title", "Inline",
+	)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<html>
+  <head>
+    <title>
+      Inline
+    </title>
+  </head>
+  <body>
+    <main>
+      <div>
+        embedded: true
+      </div>
+    </main>
+  </body>
+</html>`, gohtml.Format(string(b)), "unexpected bytes returned")
+}
@@ -0,0 +1,70 @@
+package templater
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"slices"
+
+	"github.com/angelbeltran/templater/funcs"
+)
+
+// lazyComponent is the implementation of the `lazyComponent` template function.
+// Rather than executing the component, it emits a placeholder element naming the component
+// and holding its props as JSON, for client-side code to fetch the component, eg via
+// ExecuteComponent, and replace the placeholder with it once it's needed.
+// Only the given props are included, not those inherited from the parent, as they must be serializable.
+func (ec *executionContext) lazyComponent(name string, kvs ...any) (template.HTML, error) {
+	if slices.Contains(ec.cfg.DisabledComponents, name) {
+		return "", &ErrComponentDisabled{
+			Name: name,
+		}
+	}
+
+	props, err := funcs.NewKVSProps(kvs...)
+	if err != nil {
+		return "", err
+	}
+
+	return lazyPlaceholder(name, props, "", "")
+}
+
+// ifVisible is the implementation of the `ifVisible` template function.
+// Like lazyComponent, it emits a placeholder naming the component and holding the given props,
+// additionally marked with data-lazy="visible", for client-side code to load the component once the placeholder
+// is scrolled into view, eg via an IntersectionObserver. For clients without JS, the placeholder contains
+// the component executed on the server, with the parent's props, within a <noscript>.
+func (ec *executionContext) ifVisible(name string, parentProps map[string]any, kvs ...any) (template.HTML, error) {
+	props, err := funcs.NewKVSProps(kvs...)
+	if err != nil {
+		return "", err
+	}
+
+	cpy, err := ec.addCallProps(parentProps, kvs...)
+	if err != nil {
+		return "", err
+	}
+
+	fallback, err := ec.executeComponent(name, cpy)
+	if err != nil {
+		return "", err
+	}
+
+	return lazyPlaceholder(name, props, ` data-lazy="visible"`, "<noscript>"+string(ec.cfg.trimComponentOutput(fallback))+"</noscript>")
+}
+
+// lazyPlaceholder emits the placeholder element of a lazy component, with the additional attributes and content,
+// which aren't escaped.
+func lazyPlaceholder(name string, props map[string]any, attrs, content string) (template.HTML, error) {
+	b, err := json.Marshal(props)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize the props of lazy component %s: %w", name, err)
+	}
+
+	return template.HTML(fmt.Sprintf(`<div data-component="%s" data-props="%s"%s>%s</div>`,
+		template.HTMLEscapeString(name),
+		template.HTMLEscapeString(string(b)),
+		attrs,
+		content,
+	)), nil
+}
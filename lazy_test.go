@@ -0,0 +1,40 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_LazyComponent(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInline(`{{ lazyComponent "component_1" "X" "abc" "Y" 123 }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<div data-component="component_1" data-props="{&#34;X&#34;:&#34;abc&#34;,&#34;Y&#34;:123}"></div>`, string(b), "unexpected bytes returned")
+	assert.NotContains(t, string(b), "<div>", "expected the component not to be rendered")
+}
+
+func TestTemplater_IfVisible(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInline(`{{ ifVisible "badge" "Label" "new" }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<div data-component="badge" data-props="{&#34;Label&#34;:&#34;new&#34;}" data-lazy="visible">`+
+		`<noscript><div class="badge">new</div>`+"\n"+`</noscript></div>`, string(b), "unexpected bytes returned")
+}
@@ -0,0 +1,66 @@
+package templater
+
+import (
+	"html/template"
+	"reflect"
+
+	"github.com/angelbeltran/templater/funcs"
+)
+
+const (
+	// LimitRenders names the limit of Config.MaxRenders in an ErrLimitExceeded.
+	LimitRenders = "renders"
+	// LimitFuncCalls names the limit of Config.MaxFuncCalls in an ErrLimitExceeded.
+	LimitFuncCalls = "func calls"
+	// LimitComponentInstances names the limit of Config.MaxComponentInstances in an ErrLimitExceeded.
+	LimitComponentInstances = "component instances"
+)
+
+// countRender counts the execution of a component or slot against Config.MaxRenders, if set.
+func (ec *executionContext) countRender() error {
+	ec.render.renders++
+	if n := ec.cfg.MaxRenders; n > 0 && ec.render.renders > n {
+		return &ErrLimitExceeded{
+			Limit: LimitRenders,
+			Max:   n,
+		}
+	}
+	return nil
+}
+
+// countInstance counts the execution of the component of the given name against Config.MaxComponentInstances, if set.
+func (ec *executionContext) countInstance(name string) error {
+	ec.render.instances[name]++
+	if n := ec.cfg.MaxComponentInstances; n > 0 && ec.render.instances[name] > n {
+		return &ErrLimitExceeded{
+			Limit: LimitComponentInstances,
+			Max:   n,
+			Name:  name,
+		}
+	}
+	return nil
+}
+
+// limitFuncCalls wraps each function of m to count its calls against Config.MaxFuncCalls, if set.
+// Once exceeded, every call fails with an ErrLimitExceeded, aborting the execution.
+func (ec *executionContext) limitFuncCalls(m template.FuncMap) template.FuncMap {
+	if ec.cfg.MaxFuncCalls <= 0 {
+		return m
+	}
+
+	return funcs.Wrap(m, func(name string, fn any) any {
+		return funcs.Decorate(fn, func(args []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value {
+			ec.render.funcCalls++
+			if ec.render.funcCalls > ec.cfg.MaxFuncCalls {
+				// text/template recovers panics of functions, returning them as execution errors,
+				// which suits functions without an error result
+				panic(&ErrLimitExceeded{
+					Limit: LimitFuncCalls,
+					Max:   ec.cfg.MaxFuncCalls,
+				})
+			}
+
+			return call(args)
+		})
+	})
+}
@@ -0,0 +1,136 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_Limits(t *testing.T) {
+	type (
+		Args struct {
+			MaxRenders            int
+			MaxFuncCalls          int
+			MaxComponentInstances int
+			Body                  string
+		}
+		Expected struct {
+			Bytes string
+			Error *ErrLimitExceeded
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	const body = `{{ range .Items }}{{ component "badge" "Label" . }}{{ end }}`
+
+	tests := []Test{
+		{
+			Name: "Given no limits " +
+				"Then every component is rendered",
+			Args: Args{
+				Body: body,
+			},
+			Expected: Expected{
+				Bytes: "<div class=\"badge\">a</div>\n<div class=\"badge\">b</div>\n<div class=\"badge\">c</div>\n",
+			},
+		},
+		{
+			Name: "Given a render limit not exceeded " +
+				"Then every component is rendered",
+			Args: Args{
+				MaxRenders: 3,
+				Body:       body,
+			},
+			Expected: Expected{
+				Bytes: "<div class=\"badge\">a</div>\n<div class=\"badge\">b</div>\n<div class=\"badge\">c</div>\n",
+			},
+		},
+		{
+			Name: "Given a render limit exceeded " +
+				"Then the execution is aborted",
+			Args: Args{
+				MaxRenders: 2,
+				Body:       body,
+			},
+			Expected: Expected{
+				Error: &ErrLimitExceeded{Limit: LimitRenders, Max: 2},
+			},
+		},
+		{
+			Name: "Given a func call limit exceeded " +
+				"Then the execution is aborted",
+			Args: Args{
+				MaxFuncCalls: 4,
+				Body:         `{{ range .Items }}{{ urlJoin "/" . }}{{ end }}{{ urlJoin "/" "d" }}{{ urlJoin "/" "e" }}`,
+			},
+			Expected: Expected{
+				Error: &ErrLimitExceeded{Limit: LimitFuncCalls, Max: 4},
+			},
+		},
+		{
+			Name: "Given a func call limit exceeded within components " +
+				"Then the execution is aborted",
+			Args: Args{
+				MaxFuncCalls: 3,
+				Body:         body + `{{ component "badge" "Label" "d" }}`,
+			},
+			Expected: Expected{
+				Error: &ErrLimitExceeded{Limit: LimitFuncCalls, Max: 3},
+			},
+		},
+		{
+			Name: "Given a component instance limit not exceeded by any one component " +
+				"Then every component is rendered",
+			Args: Args{
+				MaxComponentInstances: 3,
+				Body:                  body + `{{ component "card" "Title" "d" }}`,
+			},
+			Expected: Expected{
+				Bytes: "<div class=\"badge\">a</div>\n<div class=\"badge\">b</div>\n<div class=\"badge\">c</div>\n" +
+					"<div class=\"card\">d</div>\n",
+			},
+		},
+		{
+			Name: "Given a component instance limit exceeded " +
+				"Then the execution is aborted",
+			Args: Args{
+				MaxComponentInstances: 2,
+				Body:                  body,
+			},
+			Expected: Expected{
+				Error: &ErrLimitExceeded{Limit: LimitComponentInstances, Max: 2, Name: "badge"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tm := new(Templater).With(Config{
+				Dirs: DirsConfig{
+					Base:       "test_dir/test_templates",
+					Pages:      "test_pages",
+					Components: "test_components",
+				},
+				MaxRenders:            test.Args.MaxRenders,
+				MaxFuncCalls:          test.Args.MaxFuncCalls,
+				MaxComponentInstances: test.Args.MaxComponentInstances,
+			})
+
+			b, err := tm.ExecuteInline(test.Args.Body, "Items", []string{"a", "b", "c"})
+
+			if test.Expected.Error == nil {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Bytes, string(b), "unexpected output")
+			} else {
+				var le *ErrLimitExceeded
+				require.ErrorAs(t, err, &le, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Error, le, "unexpected error returned")
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ExecutePageWithLinks is ExecutePage, additionally returning a preload Link header value
+// for each stylesheet, script, and preload link found in the page's <head>.
+// They're intended for use in the Link header of a 103 Early Hints or the final response, eg
+//
+//	</style.css>; rel=preload; as=style
+func (tm *Templater) ExecutePageWithLinks(name string, kvs ...any) ([]byte, []string, error) {
+	b, err := tm.ExecutePage(name, kvs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, headLinks(b), nil
+}
+
+// headLinks scans the <head> of the html document for assets worth preloading,
+// returning them formatted as Link header values.
+func headLinks(doc []byte) []string {
+	var links []string
+
+	z := html.NewTokenizer(bytes.NewReader(doc))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return links
+		case html.EndTagToken:
+			if name, _ := z.TagName(); atom.Lookup(name) == atom.Head {
+				return links
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+
+			switch t.DataAtom {
+			case atom.Body:
+				return links
+			case atom.Link:
+				href := attr(t, "href")
+				if href == "" {
+					continue
+				}
+
+				switch rel := strings.ToLower(attr(t, "rel")); rel {
+				case "stylesheet":
+					links = append(links, formatLink(href, "style", hasAttr(t, "crossorigin")))
+				case "preload":
+					if as := attr(t, "as"); linkAsPattern.MatchString(as) {
+						links = append(links, formatLink(href, as, hasAttr(t, "crossorigin")))
+					}
+				}
+			case atom.Script:
+				if src := attr(t, "src"); src != "" {
+					links = append(links, formatLink(src, "script", hasAttr(t, "crossorigin")))
+				}
+			}
+		}
+	}
+}
+
+// formatLink returns the Link header value preloading href, percent-encoding the characters of href
+// that would end its URI reference or the link-value, eg > or ,, so a url can't inject further parameters or links.
+func formatLink(href, as string, crossorigin bool) string {
+	var b strings.Builder
+	for i := 0; i < len(href); i++ {
+		if c := href[i]; c <= ' ' || c >= 0x7f || strings.IndexByte(`<>;,"`, c) >= 0 {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+
+	link := fmt.Sprintf("<%s>; rel=preload; as=%s", b.String(), as)
+	if crossorigin {
+		link += "; crossorigin"
+	}
+	return link
+}
+
+// linkAsPattern matches the values of the as attribute of a preload link that can be used in a Link header as is.
+var linkAsPattern = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+func attr(t html.Token, key string) string {
+	for _, a := range t.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(t html.Token, key string) bool {
+	for _, a := range t.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecutePageWithLinks(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, links, err := tm.ExecutePageWithLinks("styled_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Contains(t, string(b), `<link rel="stylesheet" href="/style.css">`, "unexpected bytes returned")
+	assert.Equal(t, []string{
+		"</style.css>; rel=preload; as=style",
+		"</app.js>; rel=preload; as=script",
+		"</font.woff2>; rel=preload; as=font; crossorigin",
+	}, links, "unexpected links returned")
+}
+
+func TestHeadLinks(t *testing.T) {
+	type (
+		Args struct {
+			Doc string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected []string
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given a url containing a > " +
+				"Then it's percent-encoded",
+			Args: Args{
+				Doc: `<head><script src="/app.js>; rel=preload; as=script, </evil.js"></script></head>`,
+			},
+			Expected: []string{
+				"</app.js%3E%3B%20rel=preload%3B%20as=script%2C%20%3C/evil.js>; rel=preload; as=script",
+			},
+		},
+		{
+			Name: "Given a url containing a ; or , " +
+				"Then they're percent-encoded",
+			Args: Args{
+				Doc: `<head><link rel="stylesheet" href="/a;b,c.css"></head>`,
+			},
+			Expected: []string{
+				"</a%3Bb%2Cc.css>; rel=preload; as=style",
+			},
+		},
+		{
+			Name: "Given a preload link with an as containing parameters " +
+				"Then it's skipped",
+			Args: Args{
+				Doc: `<head><link rel="preload" href="/a.js" as="script; crossorigin, </b.js>"></head>`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, headLinks([]byte(test.Args.Doc)), "unexpected links returned")
+		})
+	}
+}
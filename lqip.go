@@ -0,0 +1,108 @@
+package templater
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// lqipSize is the width or height, whichever is larger, of placeholder images.
+const lqipSize = 16
+
+// lqip is the implementation of the `lqip` template function.
+// It returns a low quality image placeholder of the PNG, JPEG, or GIF file of the given path in the images directory,
+// as a data URI of the image scaled down to at most 16 pixels wide or high, eg
+//
+//	<img src="{{ lqip "cat.jpg" }}" data-src="/images/cat.jpg">
+//
+// Scaled up by the browser, it appears as a blurred preview of the image until the image itself loads.
+// Missing or invalid images result in empty.
+// Placeholders are cached, so changes to images require a new Templater.
+func (ec *executionContext) lqip(name string) template.URL {
+	if !fs.ValidPath(name) || strings.Contains(name, "\\") {
+		return ""
+	}
+
+	filename := path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Images, name)
+
+	if v, ok := ec.caches.lqips.Load(filename); ok {
+		return v.(template.URL)
+	}
+
+	uri := lqipDataURI(ec.cfg.FS, filename)
+	ec.caches.lqips.Store(filename, uri)
+
+	return uri
+}
+
+func lqipDataURI(fsys fs.FS, filename string) template.URL {
+	f, err := fsys.Open(filename)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return ""
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, downscale(src, lqipSize)); err != nil {
+		return ""
+	}
+
+	return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+// downscale returns the image scaled down to fit within size by size pixels, preserving its aspect ratio,
+// each pixel being the average of the pixels of the image it covers.
+func downscale(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w == 0 || h == 0 {
+		return src
+	}
+
+	dw, dh := size, size
+	if w > h {
+		dh = max(1, h*size/w)
+	} else {
+		dw = max(1, w*size/h)
+	}
+	dw, dh = min(dw, w), min(dh, h)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		y0, y1 := b.Min.Y+y*h/dh, b.Min.Y+(y+1)*h/dh
+		for x := range dw {
+			x0, x1 := b.Min.X+x*w/dw, b.Min.X+(x+1)*w/dw
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					r, g, bl, a = r+uint64(c.R), g+uint64(c.G), bl+uint64(c.B), a+uint64(c.A)
+					n++
+				}
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,47 @@
+package templater
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_LQIP(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInline(`{{ lqip "sample.png" }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	uri, ok := strings.CutPrefix(html.UnescapeString(string(b)), "data:image/png;base64,")
+	require.True(t, ok, "expected a png data uri: %s", b)
+
+	raw, err := base64.StdEncoding.DecodeString(uri)
+	require.NoError(t, err, "unexpected error decoding data uri: %+v", err)
+
+	img, err := png.Decode(bytes.NewReader(raw))
+	require.NoError(t, err, "unexpected error decoding placeholder: %+v", err)
+	assert.Equal(t, 16, img.Bounds().Dx(), "unexpected placeholder width")
+	assert.Equal(t, 8, img.Bounds().Dy(), "unexpected placeholder height")
+
+	b, err = tm.ExecuteInline(`<img src="{{ lqip "sample.png" }}">`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Contains(t, string(b), `<img src="data:image/png;base64,`, "expected the data uri to be usable as an image src")
+
+	for _, name := range []string{"missing.png", "../layout.html.tmpl"} {
+		b, err = tm.ExecuteInline(`{{ lqip .Name }}`, "Name", name)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Empty(t, string(b), "expected no placeholder for %s", name)
+	}
+}
@@ -0,0 +1,39 @@
+package templater
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+)
+
+// The behaviors of Config.MissingComponentBehavior.
+const (
+	// MissingComponentError fails the execution, as for any other error. It's the default.
+	MissingComponentError = ""
+	// MissingComponentPlaceholder emits a placeholder naming the missing component, eg during development.
+	MissingComponentPlaceholder = "placeholder"
+	// MissingComponentOmit emits nothing in place of the missing component, eg in production.
+	MissingComponentOmit = "omit"
+)
+
+// executeComponentOrMissing executes the component of the given name as the `component` template function,
+// handling a missing component file as configured by Config.MissingComponentBehavior.
+func (ec *executionContext) executeComponentOrMissing(name string, props map[string]any) ([]byte, error) {
+	b, err := ec.executeComponent(name, props)
+
+	var te *ErrNotTemplateFileFound
+	if err == nil || !errors.As(err, &te) {
+		return b, err
+	}
+
+	switch ec.cfg.MissingComponentBehavior {
+	case MissingComponentError:
+		return nil, err
+	case MissingComponentPlaceholder:
+		return fmt.Appendf(nil, `<div class="missing-component">missing component: %s</div>`, template.HTMLEscapeString(name)), nil
+	case MissingComponentOmit:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown missing component behavior %q: %w", ec.cfg.MissingComponentBehavior, err)
+	}
+}
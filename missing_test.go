@@ -0,0 +1,80 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_MissingComponentBehavior(t *testing.T) {
+	type (
+		Expected struct {
+			Bytes string
+			Error bool
+		}
+		Test struct {
+			Name     string
+			Behavior string
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given the default behavior " +
+				"Then the execution fails",
+			Behavior: MissingComponentError,
+			Expected: Expected{
+				Error: true,
+			},
+		},
+		{
+			Name: "Given the placeholder behavior " +
+				"Then a placeholder naming the component is emitted",
+			Behavior: MissingComponentPlaceholder,
+			Expected: Expected{
+				Bytes: `<p><div class="missing-component">missing component: no_such/component</div></p>`,
+			},
+		},
+		{
+			Name: "Given the omit behavior " +
+				"Then nothing is emitted",
+			Behavior: MissingComponentOmit,
+			Expected: Expected{
+				Bytes: `<p></p>`,
+			},
+		},
+		{
+			Name: "Given an unknown behavior " +
+				"Then the execution fails",
+			Behavior: "ignore",
+			Expected: Expected{
+				Error: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tm := new(Templater).With(Config{
+				Dirs: DirsConfig{
+					Base:       "test_dir/test_templates",
+					Pages:      "test_pages",
+					Components: "test_components",
+				},
+				MissingComponentBehavior: test.Behavior,
+			})
+
+			b, err := tm.ExecuteInline(`<p>{{ component "no_such/component" }}</p>`)
+
+			if !test.Expected.Error {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Bytes, string(b), "unexpected output")
+			} else {
+				var te *ErrNotTemplateFileFound
+				assert.ErrorAs(t, err, &te, "unexpected error returned: %+v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package templater
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// RenderNegotiated is RenderToResponse, except clients preferring JSON to HTML by their Accept header,
+// eg API clients sending Accept: application/json, receive the props the template would be executed with
+// as JSON instead, including its PathParams, so one route may serve both browsers and API clients.
+// The template is resolved as by Execute, pages first, but not executed.
+// Props that can't be serialized as JSON, eg funcs, result in an error.
+func (tm *Templater) RenderNegotiated(w http.ResponseWriter, r *http.Request, name string, kvs ...any) error {
+	w.Header().Add("Vary", "Accept")
+
+	if !prefersJSON(r.Header.Get("Accept")) {
+		return tm.RenderToResponse(w, r, name, kvs...)
+	}
+
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return err
+	}
+
+	if err := tm.newContext().resolvePathParams(name, props); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(props)
+	if err != nil {
+		return fmt.Errorf("failed to serialize the props of %s: %w", name, err)
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "application/json")
+	h.Set("Content-Length", strconv.Itoa(len(b)))
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method == http.MethodHead {
+		return nil
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// resolvePathParams sets the PathParams prop of the template of the given name, pages first,
+// as Execute would when executing it.
+func (ec *executionContext) resolvePathParams(name string, props map[string]any) error {
+	if _, overridden := ec.caches.override(RenderKindPage, name); overridden {
+		props["PathParams"] = map[string]any{}
+		return nil
+	}
+
+	match, filename, perr := ec.findTemplateFile(name, path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Pages), props)
+	if perr != nil {
+		var te *ErrNotTemplateFileFound
+		if !errors.As(perr, &te) {
+			return perr
+		}
+
+		registered, m, f, cerr := ec.resolveComponent(name, path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Components), props)
+		if cerr != nil {
+			return errors.Join(perr, cerr)
+		}
+		if registered != nil {
+			props["PathParams"] = map[string]any{}
+			return nil
+		}
+		match, filename = m, f
+	}
+
+	var err error
+	props["PathParams"], _, err = getPathParameters(match, filename)
+	return err
+}
+
+// prefersJSON reports whether the Accept header prefers application/json to text/html.
+// Without an Accept header, HTML is preferred.
+func prefersJSON(accept string) bool {
+	return acceptQuality(accept, "application/json") > acceptQuality(accept, "text/html")
+}
+
+// acceptQuality returns the quality the Accept header assigns the media type, by its most specific matching range,
+// eg 0.8 for application/json given "text/html, application/*;q=0.8", or 0 if none match.
+func acceptQuality(accept, mediaType string) float64 {
+	typ, _, _ := strings.Cut(mediaType, "/")
+
+	quality, specificity := 0.0, -1
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+
+		var s int
+		switch strings.ToLower(strings.TrimSpace(params[0])) {
+		case mediaType:
+			s = 2
+		case typ + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s <= specificity {
+			continue
+		}
+
+		q := 1.0
+		for _, p := range params[1:] {
+			if k, v, ok := strings.Cut(strings.TrimSpace(p), "="); ok && strings.EqualFold(k, "q") {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+		}
+
+		quality, specificity = q, s
+	}
+
+	return quality
+}
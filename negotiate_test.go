@@ -0,0 +1,95 @@
+package templater
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_RenderNegotiated(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	t.Run("Given a request accepting JSON "+
+		"Then the props are returned as JSON", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/top_dir/abc/the_page", nil)
+		r.Header.Set("Accept", "application/json")
+
+		w := httptest.NewRecorder()
+		require.NoError(t, tm.RenderNegotiated(w, r, "top_dir/abc/the_page", "Title", "Hello"))
+
+		assert.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"), "unexpected content type")
+		assert.Equal(t, "Accept", w.Header().Get("Vary"), "unexpected vary header")
+		assert.JSONEq(t, `{"Title":"Hello","PathParams":{"param1":"abc"}}`, w.Body.String(), "unexpected body")
+	})
+
+	t.Run("Given a request accepting HTML "+
+		"Then the page is rendered", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/simple_page", nil)
+		r.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+		w := httptest.NewRecorder()
+		require.NoError(t, tm.RenderNegotiated(w, r, "simple_page"))
+
+		assert.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"), "unexpected content type")
+		assert.Equal(t, "Accept", w.Header().Get("Vary"), "unexpected vary header")
+		assert.Contains(t, w.Body.String(), "TEST", "unexpected body")
+	})
+}
+
+func TestPrefersJSON(t *testing.T) {
+	type Test struct {
+		Name     string
+		Accept   string
+		Expected bool
+	}
+
+	tests := []Test{
+		{
+			Name:     "Given no accept header Then HTML is preferred",
+			Accept:   "",
+			Expected: false,
+		},
+		{
+			Name:     "Given only JSON accepted Then JSON is preferred",
+			Accept:   "application/json",
+			Expected: true,
+		},
+		{
+			Name:     "Given a browser accept header Then HTML is preferred",
+			Accept:   "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			Expected: false,
+		},
+		{
+			Name:     "Given JSON ranked above HTML Then JSON is preferred",
+			Accept:   "text/html;q=0.5, application/json",
+			Expected: true,
+		},
+		{
+			Name:     "Given an application wildcard ranked above any Then JSON is preferred",
+			Accept:   "application/*, */*;q=0.1",
+			Expected: true,
+		},
+		{
+			Name:     "Given JSON and HTML ranked equally Then HTML is preferred",
+			Accept:   "application/json, text/html",
+			Expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, prefersJSON(test.Accept), "unexpected preference")
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package templater
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"html/template"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// NewNonce returns a random nonce for a Content-Security-Policy, eg
+//
+//	nonce := templater.NewNonce()
+//	w.Header().Set("Content-Security-Policy", "script-src 'nonce-"+nonce+"'")
+//	b, err := tm.WithNonce(nonce).ExecutePage("home")
+func NewNonce() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// WithNonce returns a copy of the Templater which provides the CSP nonce to the `cspNonce` template function
+// of every execution, and adds it to inline scripts and styles if Config.AutoNonce is set.
+// A nonce must not be reused across responses.
+func (tm *Templater) WithNonce(nonce string) *Templater {
+	cpy := *tm
+	cpy.nonce = nonce
+	return &cpy
+}
+
+// cspNonce is the implementation of the `cspNonce` template function.
+func (ec *executionContext) cspNonce() string {
+	return ec.nonce
+}
+
+// addNonces adds the nonce attribute to each <script> without a src, and each <style>, without a nonce.
+func addNonces(doc []byte, nonce string) []byte {
+	var b bytes.Buffer
+	attr := ` nonce="` + template.HTMLEscapeString(nonce) + `"`
+
+	z := html.NewTokenizer(bytes.NewReader(doc))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return b.Bytes()
+		}
+
+		raw := z.Raw()
+		if tt != html.StartTagToken {
+			b.Write(raw)
+			continue
+		}
+
+		name, hasAttr := z.TagName()
+		a := atom.Lookup(name)
+		if a != atom.Script && a != atom.Style {
+			b.Write(raw)
+			continue
+		}
+
+		// copy the raw tag, as reading its attributes may change it
+		tag := string(raw)
+
+		var skip bool
+		for hasAttr {
+			var key []byte
+			key, _, hasAttr = z.TagAttr()
+			if k := strings.ToLower(string(key)); k == "nonce" || (a == atom.Script && k == "src") {
+				skip = true
+			}
+		}
+
+		if skip {
+			b.WriteString(tag)
+			continue
+		}
+
+		b.WriteString(tag[:1+len(name)])
+		b.WriteString(attr)
+		b.WriteString(tag[1+len(name):])
+	}
+}
@@ -0,0 +1,41 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_AutoNonce(t *testing.T) {
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	}
+
+	const body = `<style>p { color: red; }</style>` +
+		`<script>console.log("inline")</script>` +
+		`<script src="/app.js"></script>` +
+		`<script nonce="{{ cspNonce }}">console.log("explicit")</script>`
+
+	b, err := new(Templater).With(cfg).WithNonce("abc123").ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<style>p { color: red; }</style>`+
+		`<script>console.log("inline")</script>`+
+		`<script src="/app.js"></script>`+
+		`<script nonce="abc123">console.log("explicit")</script>`, string(b), "expected no nonces added unless enabled")
+
+	cfg.AutoNonce = true
+
+	b, err = new(Templater).With(cfg).WithNonce("abc123").ExecuteInline(body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<style nonce="abc123">p { color: red; }</style>`+
+		`<script nonce="abc123">console.log("inline")</script>`+
+		`<script src="/app.js"></script>`+
+		`<script nonce="abc123">console.log("explicit")</script>`, string(b), "unexpected bytes returned")
+
+	assert.NotEqual(t, NewNonce(), NewNonce(), "expected nonces to be random")
+}
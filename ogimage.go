@@ -0,0 +1,53 @@
+package templater
+
+import (
+	"fmt"
+	"html/template"
+	"image"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/angelbeltran/templater/funcs"
+)
+
+// ogImagePriority is the `headItem` priority of the meta tags emitted by the `ogImage` template function.
+const ogImagePriority = 0
+
+// ogImage is the implementation of the `ogImage` template function.
+// It stores the og:image, og:image:width, and og:image:height meta tags of the PNG, JPEG, or GIF file
+// of the given path in the images directory as a head item, for the `headItems` template function to emit, eg
+//
+//	{{ define "head" }}{{ ogImage "social/cat.png" }}{{ end }}
+//
+// The og:image URL is the path joined onto Config.ImagesURL.
+// Missing or invalid images store nothing. As with `headItem`, the tags are emitted once however many times
+// they're stored.
+func (ec *executionContext) ogImage(name string) (string, error) {
+	if !fs.ValidPath(name) || strings.Contains(name, "\\") {
+		return "", nil
+	}
+
+	f, err := ec.cfg.FS.Open(path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Images, name))
+	if err != nil {
+		return "", nil
+	}
+	defer f.Close()
+
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return "", nil
+	}
+
+	u, err := funcs.URLJoin(ec.cfg.ImagesURL, name)
+	if err != nil {
+		return "", err
+	}
+
+	return ec.render.headItem(ogImagePriority, fmt.Sprintf(
+		`<meta property="og:image" content="%s"><meta property="og:image:width" content="%d"><meta property="og:image:height" content="%d">`,
+		template.HTMLEscapeString(u),
+		cfg.Width,
+		cfg.Height,
+	))
+}
@@ -0,0 +1,72 @@
+package templater
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_OGImage(t *testing.T) {
+	type (
+		Args struct {
+			ImagesURL string
+			Head      string
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected string
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given an image " +
+				"Then its meta tags are emitted once, with its dimensions",
+			Args: Args{
+				Head: `{{ ogImage "sample.png" }}{{ ogImage "sample.png" }}`,
+			},
+			Expected: `<meta property="og:image" content="/images/sample.png">` +
+				`<meta property="og:image:width" content="64"><meta property="og:image:height" content="32">`,
+		},
+		{
+			Name: "Given an images url " +
+				"Then the image url is relative to it",
+			Args: Args{
+				ImagesURL: "https://cdn.example.com/img/",
+				Head:      `{{ ogImage "sample.png" }}`,
+			},
+			Expected: `<meta property="og:image" content="https://cdn.example.com/img/sample.png">` +
+				`<meta property="og:image:width" content="64"><meta property="og:image:height" content="32">`,
+		},
+		{
+			Name: "Given a missing image " +
+				"Then nothing is emitted",
+			Args: Args{
+				Head: `{{ ogImage "missing.png" }}`,
+			},
+			Expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tm := new(Templater).With(Config{
+				Dirs: DirsConfig{
+					Base:       "test_dir/test_templates",
+					Pages:      "test_pages",
+					Components: "test_components",
+				},
+				ImagesURL: test.Args.ImagesURL,
+			})
+
+			b, err := tm.ExecuteInlinePage(`<head>{{ block "head" . }}{{ end }}{{ headItems }}</head>`, test.Args.Head, "")
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+
+			head := strings.TrimSuffix(strings.TrimPrefix(string(b), "<head>"), "</head>")
+			assert.Equal(t, test.Expected, head, "unexpected head")
+		})
+	}
+}
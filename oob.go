@@ -0,0 +1,76 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"maps"
+)
+
+// FragmentSpec describes a fragment of an out-of-band swap response executed by ExecuteOOB.
+// Either Component, or Page and Block, name the template of the fragment.
+type FragmentSpec struct {
+	// Component is the name of the component executed for the fragment.
+	Component string
+	// Page and Block name a template defined within a page, as executed by ExecutePageBlock.
+	Page  string
+	Block string
+	// Props are the props of the fragment, overriding those of the execution.
+	Props map[string]any
+	// Target is the id of the element of the page the fragment replaces.
+	Target string
+	// Swap is the value of the hx-swap-oob attribute. Defaults to "true", replacing the target element.
+	Swap string
+}
+
+// ExecuteOOB executes each fragment, wrapping it in an element with the fragment's target id
+// and an hx-swap-oob attribute, so a single HTMX response may update several regions of a page, eg
+//
+//	<div id="cart-count" hx-swap-oob="true">3</div>
+//
+// Each fragment is given the props of kvs, overridden by the props of its spec.
+func (tm *Templater) ExecuteOOB(fragments []FragmentSpec, kvs ...any) ([]byte, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	render := newRenderState()
+
+	buf := new(bytes.Buffer)
+	for i, f := range fragments {
+		if f.Target == "" {
+			return nil, fmt.Errorf("fragment %d has no target", i)
+		}
+
+		fprops := maps.Clone(props)
+		maps.Copy(fprops, f.Props)
+
+		ec := tm.newContext()
+		ec.render = render
+
+		var b []byte
+		switch {
+		case f.Component != "":
+			b, err = ec.executeComponent(f.Component, fprops)
+		case f.Page != "" && f.Block != "":
+			b, err = ec.executePageBlock(f.Page, f.Block, fprops)
+		default:
+			return nil, fmt.Errorf("fragment %d names neither a component nor a page block", i)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute fragment %d: %w", i, err)
+		}
+
+		swap := f.Swap
+		if swap == "" {
+			swap = "true"
+		}
+
+		fmt.Fprintf(buf, `<div id="%s" hx-swap-oob="%s">`, template.HTMLEscapeString(f.Target), template.HTMLEscapeString(swap))
+		buf.Write(ec.cfg.trimComponentOutput(b))
+		buf.WriteString("</div>\n")
+	}
+
+	return tm.finishOutput(buf.Bytes(), nil)
+}
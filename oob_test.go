@@ -0,0 +1,35 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecuteOOB(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		TrimComponentOutput: true,
+	})
+
+	b, err := tm.ExecuteOOB([]FragmentSpec{
+		{Component: "hero", Props: map[string]any{"Title": "Updated"}, Target: "hero"},
+		{Component: "submit_button", Props: map[string]any{"Label": "Saved"}, Target: "actions", Swap: "innerHTML"},
+	}, "Site", "Pets")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<div id="hero" hx-swap-oob="true"><section class="hero">Updated - Pets</section></div>`+"\n"+
+		`<div id="actions" hx-swap-oob="innerHTML"><button id="submit">Saved</button></div>`+"\n", string(b), "unexpected bytes returned")
+
+	b, err = tm.ExecuteOOB([]FragmentSpec{{Page: "blocks_page", Block: "sidebar", Target: "sidebar"}}, "X", "x")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Regexp(t, `^<div id="sidebar" hx-swap-oob="true"><aside>`, string(b), "unexpected bytes returned")
+
+	_, err = tm.ExecuteOOB([]FragmentSpec{{Component: "hero"}})
+	assert.Error(t, err, "expected an error for a fragment without a target")
+}
@@ -0,0 +1,135 @@
+package templater
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// overlayFS is the Config.FS of a Templater with Config.OverlayDirs. It resolves each path within Dirs.Base
+// to the same path within the first of the overlay directories having it, otherwise within Dirs.Base itself,
+// and lists the entries of a directory within Dirs.Base and the overlay directories together.
+type overlayFS struct {
+	fsys     fs.FS
+	base     string
+	overlays []string
+}
+
+func newOverlayFS(fsys fs.FS, base string, overlays []string) *overlayFS {
+	cleaned := make([]string, len(overlays))
+	for i, dir := range overlays {
+		cleaned[i] = path.Clean(dir)
+	}
+
+	return &overlayFS{
+		fsys:     fsys,
+		base:     path.Clean(base),
+		overlays: cleaned,
+	}
+}
+
+// candidates returns the paths the path may resolve to, in order of precedence.
+func (o *overlayFS) candidates(name string) []string {
+	var rel string
+	switch {
+	case o.base == ".":
+		rel = name
+	case name == o.base:
+		rel = "."
+	default:
+		var ok bool
+		if rel, ok = strings.CutPrefix(name, o.base+"/"); !ok {
+			return []string{name}
+		}
+	}
+
+	paths := make([]string, 0, len(o.overlays)+1)
+	for _, dir := range o.overlays {
+		paths = append(paths, path.Join(dir, rel))
+	}
+	return append(paths, name)
+}
+
+// resolve returns the path the file of the given path resolves to, and the directory,
+// one of the overlay directories or Dirs.Base, it resolves within.
+func (o *overlayFS) resolve(name string) (string, string) {
+	// a path outside of Dirs.Base has no other candidates
+	if candidates := o.candidates(name); len(candidates) > 1 {
+		for i, p := range candidates[:len(o.overlays)] {
+			if _, err := fs.Stat(o.fsys, p); err == nil {
+				return p, o.overlays[i]
+			}
+		}
+	}
+	return name, o.base
+}
+
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	var err error
+	for _, p := range o.candidates(name) {
+		var f fs.File
+		if f, err = o.fsys.Open(p); err == nil {
+			return f, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	// the error of Dirs.Base itself
+	return nil, err
+}
+
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	var found bool
+	var err error
+	for _, p := range o.candidates(name) {
+		var dirEntries []fs.DirEntry
+		if dirEntries, err = fs.ReadDir(o.fsys, p); errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		found = true
+
+		for _, e := range dirEntries {
+			if !slices.ContainsFunc(entries, func(existing fs.DirEntry) bool {
+				return existing.Name() == e.Name()
+			}) {
+				entries = append(entries, e)
+			}
+		}
+	}
+	if !found {
+		return nil, err
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return entries, nil
+}
+
+// overlayDirFS is a directory of an overlayFS, as an fs.FS of its own, as returned by subFS.
+// Unlike fs.Sub, the directory needn't be a valid fs.FS path, eg Dirs.Base may be absolute.
+type overlayDirFS struct {
+	o   *overlayFS
+	dir string
+}
+
+func (d overlayDirFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	return d.o.Open(path.Join(d.dir, name))
+}
+
+func (d overlayDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	return d.o.ReadDir(path.Join(d.dir, name))
+}
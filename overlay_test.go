@@ -0,0 +1,86 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_OverlayDirs(t *testing.T) {
+	type (
+		Args struct {
+			Name string
+			KVs  []any
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected string
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given a component within the overlay directory " +
+				"Then it shadows the component of the base directory",
+			Args: Args{
+				Name: "badge",
+				KVs:  []any{"Label", "new"},
+			},
+			Expected: "<span class=\"acme-badge\">new</span>\n",
+		},
+		{
+			Name: "Given a component missing from the overlay directory " +
+				"Then the component of the base directory is used",
+			Args: Args{
+				Name: "card",
+				KVs:  []any{"Title", "Hi"},
+			},
+			Expected: "<div class=\"card\">Hi</div>\n",
+		},
+		{
+			Name: "Given a component only within the overlay directory " +
+				"Then it's used",
+			Args: Args{
+				Name: "promo",
+				KVs:  []any{"Text", "Sale"},
+			},
+			Expected: "<aside class=\"acme-promo\">Sale</aside>\n",
+		},
+	}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		OverlayDirs: []string{"test_dir/overlay_acme"},
+	})
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b, err := tm.ExecuteComponent(test.Args.Name, test.Args.KVs...)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+			assert.Equal(t, test.Expected, string(b), "unexpected output")
+		})
+	}
+
+	t.Run("Given a page of the base directory using a shadowed component "+
+		"Then the base layout and page are used with the overlay component", func(t *testing.T) {
+		b, err := tm.ExecutePage("badges_page")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Contains(t, string(b), "<title>ABC</title>", "expected the base layout")
+		assert.Contains(t, string(b), `<span class="acme-badge">one</span>`, "expected the overlay component")
+		assert.NotContains(t, string(b), `class="badge"`, "expected the base component to be shadowed")
+	})
+
+	t.Run("Given the components of the base and overlay directories "+
+		"Then they're listed together", func(t *testing.T) {
+		unused, possiblyUnused, err := tm.UnusedComponents()
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Contains(t, append(unused, possiblyUnused...), "promo", "expected the overlay component to be listed")
+		assert.Contains(t, append(unused, possiblyUnused...), "card.skeleton", "expected the base components to be listed")
+	})
+}
@@ -0,0 +1,44 @@
+package templater
+
+import "fmt"
+
+// OverrideKindLayout is the kind of template Override replaces the layout with.
+// Pages and components are overridden with RenderKindPage and RenderKindComponent respectively.
+const OverrideKindLayout = "layout"
+
+// Override replaces the template of the given kind and name with the source, without touching disk,
+// eg to stub templates in the tests of consuming code.
+// kind is one of OverrideKindLayout, RenderKindPage, or RenderKindComponent. name is ignored for the layout.
+// Overridden components are registered as by RegisterComponent.
+// Overridden pages have no path parameters.
+// Overrides apply to the Templater and its copies.
+func (tm *Templater) Override(kind, name, source string) error {
+	switch kind {
+	case OverrideKindLayout:
+		tm.caches.overrides.Store(overrideKey{kind: kind}, source)
+	case RenderKindPage:
+		tm.caches.overrides.Store(overrideKey{kind: kind, name: name}, source)
+	case RenderKindComponent:
+		return tm.RegisterComponent(name, source)
+	default:
+		return fmt.Errorf("templates of kind %q cannot be overridden", kind)
+	}
+
+	return nil
+}
+
+type overrideKey struct {
+	kind string
+	name string
+}
+
+// override returns the source overriding the template of the given kind and name, if any.
+func (c *caches) override(kind, name string) (string, bool) {
+	if kind == OverrideKindLayout {
+		name = ""
+	}
+	if source, ok := c.overrides.Load(overrideKey{kind: kind, name: name}); ok {
+		return source.(string), true
+	}
+	return "", false
+}
@@ -0,0 +1,37 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_Override(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	require.NoError(t, tm.Override(OverrideKindLayout, "", `<main>{{ block "body" . }}{{ end }}</main>`))
+
+	b, err := tm.ExecutePage("simple_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Regexp(t, `^<main>\s*<div>\s*TEST\s*</div>\s*</main>$`, string(b), "expected the page to be executed within the stub layout")
+
+	require.NoError(t, tm.Override(RenderKindPage, "simple_page", `<p>{{ component "component_1" }}</p>`))
+	require.NoError(t, tm.Override(RenderKindComponent, "component_1", `stubbed`))
+
+	b, err = tm.ExecutePage("simple_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<main><p>stubbed</p></main>`, string(b), "expected the stub page and component to be used")
+
+	assert.Error(t, tm.Override("partial", "x", ``), "expected an error for an unknown kind")
+
+	b, err = new(Templater).With(tm.cfg).ExecutePage("simple_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Contains(t, string(b), "<!DOCTYPE html>", "expected overrides to be scoped to the Templater")
+}
@@ -0,0 +1,114 @@
+package templater
+
+import (
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
+)
+
+// poller periodically stats the template files, reporting any that were added, modified, or removed.
+// It's a portable alternative to filesystem notifications, eg for network filesystems.
+type poller struct {
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// startPolling starts polling the templates directory if Config.PollInterval is set,
+// stopping any previous poller.
+func (tm *Templater) startPolling() {
+	tm.stopPolling()
+
+	if tm.cfg.PollInterval <= 0 {
+		return
+	}
+
+	p := &poller{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	tm.poller = p
+
+	fsys := tm.cfg.FS
+	dir := tm.cfg.Dirs.Base
+	ext := tm.cfg.FileExt
+	interval := tm.cfg.PollInterval
+	onChange := tm.cfg.OnTemplateChange
+
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		prev := statTemplateFiles(fsys, dir, ext)
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+
+			curr := statTemplateFiles(fsys, dir, ext)
+			for name, modTime := range curr {
+				if prevModTime, ok := prev[name]; !ok || !prevModTime.Equal(modTime) {
+					tm.templateChanged(name, onChange)
+				}
+			}
+			for name := range prev {
+				if _, ok := curr[name]; !ok {
+					tm.templateChanged(name, onChange)
+				}
+			}
+			prev = curr
+		}
+	}()
+}
+
+func (tm *Templater) stopPolling() {
+	if p := tm.poller; p != nil {
+		p.once.Do(func() { close(p.stop) })
+		<-p.done
+		tm.poller = nil
+	}
+}
+
+// templateChanged handles a change to the template file at the given path, relative to the base directory.
+func (tm *Templater) templateChanged(name string, onChange func(name string)) {
+	tm.Invalidate(name)
+
+	if onChange != nil {
+		onChange(name)
+	}
+}
+
+// Close stops any background work of the Templater, eg polling for template changes.
+func (tm *Templater) Close() error {
+	tm.stopPolling()
+	return nil
+}
+
+// statTemplateFiles returns the modification times of all template files in dir,
+// by their path relative to dir. Files that can't be stat'd are omitted.
+func statTemplateFiles(fsys fs.FS, dir, ext string) map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+
+	dirFS, err := subFS(fsys, dir)
+	if err != nil {
+		return modTimes
+	}
+
+	_ = fs.WalkDir(dirFS, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(p, ext) {
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil {
+			modTimes[p] = info.ModTime()
+		}
+		return nil
+	})
+
+	return modTimes
+}
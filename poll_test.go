@@ -0,0 +1,79 @@
+package templater
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_PollInterval(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "components", "polled.html.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+	require.NoError(t, os.WriteFile(file, []byte("<div>before</div>"), 0o644))
+
+	changes := make(chan string, 10)
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base: dir,
+		},
+		PollInterval: 10 * time.Millisecond,
+		OnTemplateChange: func(name string) {
+			changes <- name
+		},
+	})
+	defer tm.Close()
+
+	// let the poller take its initial snapshot
+	time.Sleep(50 * time.Millisecond)
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.WriteFile(file, []byte("<div>after</div>"), 0o644))
+	require.NoError(t, os.Chtimes(file, later, later))
+
+	select {
+	case name := <-changes:
+		assert.Equal(t, "components/polled.html.tmpl", name, "unexpected template change reported")
+	case <-time.After(time.Second):
+		t.Fatal("expected the modified template to be detected")
+	}
+
+	b, err := tm.ExecuteComponent("polled")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, "<div>after</div>", string(b), "unexpected bytes returned")
+}
+
+func TestTemplater_WithWhilePolling(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "components", "polled.html.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+	require.NoError(t, os.WriteFile(file, []byte("<div>polled</div>"), 0o644))
+
+	tm := new(Templater)
+	defer tm.Close()
+
+	// reconfigure while the poller of the previous configuration reports changes, for the race detector
+	for i := range 20 {
+		tm.With(Config{
+			Dirs: DirsConfig{
+				Base: dir,
+			},
+			PollInterval: time.Millisecond,
+		})
+
+		// let the poller take its initial snapshot, then modify the file for it to report
+		time.Sleep(3 * time.Millisecond)
+		modTime := time.Now().Add(time.Duration(i+1) * time.Minute)
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+		time.Sleep(3 * time.Millisecond)
+	}
+
+	b, err := tm.ExecuteComponent("polled")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, "<div>polled</div>", string(b), "unexpected bytes returned")
+}
@@ -0,0 +1,58 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// PostProcessor mutates the parsed output of an execution before it's serialized,
+// eg to add heading anchors, linkify text, or add footnote backlinks.
+// doc is the document node. The output of a page is parsed as a whole document,
+// whereas the output of a component is parsed as a fragment, the children of doc.
+type PostProcessor func(doc *html.Node) error
+
+// documentPattern matches the start of a whole html document, as opposed to a fragment.
+var documentPattern = regexp.MustCompile(`(?i)^\s*(<!doctype|<html)`)
+
+// postProcess parses the output, applies each of the post processors to it in order,
+// and serializes the result.
+func postProcess(b []byte, processors []PostProcessor) ([]byte, error) {
+	var doc *html.Node
+	if documentPattern.Match(b) {
+		var err error
+		if doc, err = html.Parse(bytes.NewReader(b)); err != nil {
+			return nil, fmt.Errorf("failed to parse output for post processing: %w", err)
+		}
+	} else {
+		nodes, err := html.ParseFragment(bytes.NewReader(b), &html.Node{
+			Type:     html.ElementNode,
+			Data:     "body",
+			DataAtom: atom.Body,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse output for post processing: %w", err)
+		}
+
+		doc = &html.Node{Type: html.DocumentNode}
+		for _, n := range nodes {
+			doc.AppendChild(n)
+		}
+	}
+
+	for i, process := range processors {
+		if err := process(doc); err != nil {
+			return nil, fmt.Errorf("post processor %d failed: %w", i, err)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := html.Render(buf, doc); err != nil {
+		return nil, fmt.Errorf("failed to render post processed output: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
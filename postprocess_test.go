@@ -0,0 +1,75 @@
+package templater
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestTemplater_PostProcessors(t *testing.T) {
+	headingIDs := func(doc *html.Node) error {
+		var i int
+		for n := range doc.Descendants() {
+			if n.Type != html.ElementNode || n.DataAtom != atom.H2 {
+				continue
+			}
+			i++
+
+			hasID := false
+			for _, a := range n.Attr {
+				hasID = hasID || a.Key == "id"
+			}
+			if !hasID {
+				n.Attr = append(n.Attr, html.Attribute{Key: "id", Val: fmt.Sprintf("section-%d", i)})
+			}
+		}
+		return nil
+	}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		PostProcessors: []PostProcessor{headingIDs},
+	})
+
+	t.Run("Given a fragment "+
+		"Then the post processors are applied to it", func(t *testing.T) {
+		b, err := tm.ExecuteInline(`<h2>Intro</h2><p>{{ .Text }}</p><h2 id="usage">Usage</h2>`, "Text", "a & b")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, `<h2 id="section-1">Intro</h2><p>a &amp; b</p><h2 id="usage">Usage</h2>`, string(b), "unexpected output")
+	})
+
+	t.Run("Given a document "+
+		"Then the post processors are applied to it", func(t *testing.T) {
+		b, err := tm.ExecuteInlinePage(
+			`<!DOCTYPE html><html><head><title>T</title></head><body>{{ block "body" . }}{{ end }}</body></html>`,
+			"",
+			`<h2>Intro</h2>`,
+		)
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, `<!DOCTYPE html><html><head><title>T</title></head><body><h2 id="section-1">Intro</h2></body></html>`, string(b), "unexpected output")
+	})
+
+	t.Run("Given a post processor failing "+
+		"Then its error is returned", func(t *testing.T) {
+		failure := errors.New("failure")
+
+		tm := new(Templater).With(Config{
+			Dirs: tm.cfg.Dirs,
+			PostProcessors: []PostProcessor{func(*html.Node) error {
+				return failure
+			}},
+		})
+
+		_, err := tm.ExecuteInline(`<h2>Intro</h2>`)
+		assert.ErrorIs(t, err, failure, "unexpected error returned")
+	})
+}
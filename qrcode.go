@@ -0,0 +1,67 @@
+package templater
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	qr "github.com/skip2/go-qrcode"
+)
+
+type qrcodeKey struct {
+	content string
+	size    int
+}
+
+// qrcode is the implementation of the `qrcode` template function.
+// It returns an inline SVG of a QR code encoding the content, size pixels wide and high, eg
+//
+//	{{ qrcode .TicketURL 160 }}
+//
+// Empty content, or content too long to encode, results in empty.
+// QR codes are cached by content and size.
+func (ec *executionContext) qrcode(content string, size int) template.HTML {
+	if content == "" || size <= 0 {
+		return ""
+	}
+
+	key := qrcodeKey{content: content, size: size}
+	if v, ok := ec.caches.qrcodes.Load(key); ok {
+		return v.(template.HTML)
+	}
+
+	svg := qrcodeSVG(content, size)
+	ec.caches.qrcodes.Store(key, svg)
+
+	return svg
+}
+
+func qrcodeSVG(content string, size int) template.HTML {
+	code, err := qr.New(content, qr.Medium)
+	if err != nil {
+		return ""
+	}
+	bitmap := code.Bitmap()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, len(bitmap), len(bitmap))
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, len(bitmap), len(bitmap))
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+
+			// draw each horizontal run of dark modules as a single rectangle
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+	b.WriteString(`"/></svg>`)
+
+	return template.HTML(b.String())
+}
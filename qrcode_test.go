@@ -0,0 +1,32 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_QRCode(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInline(`<div>{{ qrcode "https://example.com/tickets/123" 160 }}</div>`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Regexp(t, `^<div><svg xmlns="http://www.w3.org/2000/svg" width="160" height="160" viewBox="0 0 \d+ \d+"`, string(b), "unexpected bytes returned")
+	assert.Contains(t, string(b), `<path fill="#000" d="M`, "expected dark modules to be drawn")
+
+	again, err := tm.ExecuteInline(`<div>{{ qrcode "https://example.com/tickets/123" 160 }}</div>`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, string(b), string(again), "expected the same qr code")
+
+	b, err = tm.ExecuteInline(`<div>{{ qrcode "" 160 }}</div>`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<div></div>`, string(b), "expected no qr code for empty content")
+}
@@ -0,0 +1,32 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"text/template"
+)
+
+// executeRaw executes the component file with text/template, for Config.RawComponents,
+// so neither the component's markup nor the values it outputs are escaped.
+func (ec *executionContext) executeRaw(name, file string, props map[string]any) ([]byte, error) {
+	b, err := fs.ReadFile(ec.cfg.FS, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read raw component %s: %w", name, err)
+	}
+
+	t, err := template.New(path.Base(file)).
+		Funcs(ec.buildFuncMap(name, props)).
+		Parse(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse raw component %s: %w", name, err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, props); err != nil {
+		return nil, fmt.Errorf("failed to execute raw component %s: %w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
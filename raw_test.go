@@ -0,0 +1,47 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_RawComponents(t *testing.T) {
+	type Test struct {
+		Name          string
+		RawComponents []string
+		Expected      string
+	}
+
+	tests := []Test{
+		{
+			Name: "Given a component not listed as raw " +
+				"Then its output is escaped",
+			Expected: "<svg><text>&lt;tspan&gt;A &amp; B&lt;/tspan&gt;</text></svg>\n",
+		},
+		{
+			Name: "Given a component listed as raw " +
+				"Then its output is not escaped",
+			RawComponents: []string{"chart_label"},
+			Expected:      "<svg><text><tspan>A & B</tspan></text></svg>\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tm := new(Templater).With(Config{
+				Dirs: DirsConfig{
+					Base:       "test_dir/test_templates",
+					Pages:      "test_pages",
+					Components: "test_components",
+				},
+				RawComponents: test.RawComponents,
+			})
+
+			b, err := tm.ExecuteInline(`{{ component "chart_label" "Label" "<tspan>A & B</tspan>" }}`)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+			assert.Equal(t, test.Expected, string(b), "unexpected output")
+		})
+	}
+}
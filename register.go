@@ -0,0 +1,34 @@
+package templater
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// RegisterComponent parses the source as a component of the given name, and keeps it in memory,
+// making it usable just as a component file would be, but without the lookup and parsing costs.
+// It's useful for ubiquitous components, eg a site header, or those defined programmatically or embedded.
+// A registered component shadows any component file of the same name.
+// Registered components have no path parameters.
+func (tm *Templater) RegisterComponent(name, source string) error {
+	ec := tm.newContext()
+
+	t, err := template.New(name + tm.cfg.FileExt).
+		Funcs(ec.buildFuncMap(name, map[string]any{})).
+		Parse(source)
+	if err != nil {
+		return fmt.Errorf("failed to parse component %s: %w", name, err)
+	}
+
+	tm.caches.components.Store(name, t)
+
+	return nil
+}
+
+// registeredComponent returns the component registered under the given name, or nil if there is none.
+func (c *caches) registeredComponent(name string) *template.Template {
+	if t, ok := c.components.Load(name); ok {
+		return t.(*template.Template)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_RegisterComponent(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	require.NoError(t, tm.RegisterComponent("greeting", `<p>Hello, {{ .Name }}!</p>`))
+
+	b, err := tm.ExecuteInline(`<div>{{ component "greeting" "Name" "Ann" }}</div>`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<div><p>Hello, Ann!</p></div>`, string(b), "unexpected bytes returned")
+
+	b, err = tm.ExecuteComponent("greeting", "Name", "<Bob>")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<p>Hello, &lt;Bob&gt;!</p>`, string(b), "unexpected bytes returned")
+
+	require.NoError(t, tm.RegisterComponent("component_1", `<p>registered</p>`))
+
+	b, err = tm.ExecuteComponent("component_1")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<p>registered</p>`, string(b), "expected the registered component to shadow the component file")
+
+	assert.Error(t, tm.RegisterComponent("broken", `{{ if }}`), "expected a parse error")
+}
@@ -0,0 +1,104 @@
+package templater
+
+import (
+	"html/template"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ResolveRule is a rule of component resolution, as reported by the trace of a Resolution.
+type ResolveRule string
+
+const (
+	// ResolveRegistered resolves a name to the component registered under it, by RegisterComponent or Override.
+	ResolveRegistered ResolveRule = "registered"
+	// ResolveVariant resolves a name to the variant selected by Config.VariantSelector, when its file exists.
+	ResolveVariant ResolveRule = "variant"
+	// ResolveMatch resolves a name to the component file of the name itself, an index file,
+	// or a file matching by its path wildcards.
+	ResolveMatch ResolveRule = "match"
+	// ResolveOverlay resolves a component file to the first of Config.OverlayDirs having it, eg of a theme.
+	ResolveOverlay ResolveRule = "overlay"
+	// ResolveRoot resolves a component file to Dirs.Base, when no overlay directory has it.
+	ResolveRoot ResolveRule = "root"
+)
+
+// ResolveStep is a rule applied in resolving a component, and its result.
+type ResolveStep struct {
+	Rule ResolveRule
+	// Result is what the rule resolved to: the name of the registered component or variant,
+	// the matched file relative to the components directory, or the directory the file is read from.
+	Result string
+}
+
+// Resolution is the result of ResolveComponent.
+type Resolution struct {
+	// Path is the path of the component file executed, relative to the working directory,
+	// within the overlay directory having it, if any. It's empty for a registered component, as it has no file.
+	Path string
+	// Trace is the rules applied in resolving the component, in order.
+	Trace []ResolveStep
+}
+
+// ResolveComponent returns the path of the component file executed for the given name and props,
+// and the trace of the rules applied to find it, without executing it, eg to debug which file a name resolves to.
+// Resolution follows that of the `component` template function: disabled components are rejected with an
+// ErrComponentDisabled, then registered and overridden components are preferred, then the variant selected
+// by Config.VariantSelector, if any, then the file of the name itself, an index file, or a file matching by its path wildcards,
+// read from the first of Config.OverlayDirs having it, otherwise from Dirs.Base.
+// If no file matches, an ErrNotTemplateFileFound is returned.
+func (tm *Templater) ResolveComponent(name string, kvs ...any) (Resolution, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return Resolution{}, err
+	}
+
+	componentDir := path.Join(tm.cfg.Dirs.Base, tm.cfg.Dirs.Components)
+	registered, match, filename, err := tm.newContext().resolveComponent(name, componentDir, props)
+	if err != nil {
+		return Resolution{}, err
+	}
+	if registered != nil {
+		return Resolution{
+			Trace: []ResolveStep{{Rule: ResolveRegistered, Result: name}},
+		}, nil
+	}
+
+	var res Resolution
+	if filename != name+tm.cfg.FileExt {
+		res.Trace = append(res.Trace, ResolveStep{Rule: ResolveVariant, Result: strings.TrimSuffix(filename, tm.cfg.FileExt)})
+	}
+	res.Trace = append(res.Trace, ResolveStep{Rule: ResolveMatch, Result: match})
+
+	res.Path = path.Join(componentDir, match)
+	base := path.Clean(tm.cfg.Dirs.Base)
+	step := ResolveStep{Rule: ResolveRoot, Result: base}
+	if o, ok := tm.cfg.FS.(*overlayFS); ok {
+		var root string
+		if res.Path, root = o.resolve(res.Path); root != base {
+			step = ResolveStep{Rule: ResolveOverlay, Result: root}
+		}
+	}
+	res.Trace = append(res.Trace, step)
+
+	return res, nil
+}
+
+// resolveComponent returns the registered component of the given name, if any,
+// otherwise the path of the component file best matching it, relative to componentDir,
+// and the filename it was matched against.
+func (ec *executionContext) resolveComponent(name, componentDir string, props map[string]any) (registered *template.Template, match, filename string, err error) {
+	if slices.Contains(ec.cfg.DisabledComponents, name) {
+		return nil, "", "", &ErrComponentDisabled{
+			Name: name,
+		}
+	}
+
+	if registered = ec.caches.registeredComponent(name); registered != nil {
+		return registered, "", "", nil
+	}
+
+	match, filename, err = ec.findTemplateFile(name, componentDir, props)
+	return nil, match, filename, err
+}
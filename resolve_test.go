@@ -0,0 +1,199 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ResolveComponent(t *testing.T) {
+	type (
+		Args struct {
+			Config Config
+			Name   string
+		}
+		Expected struct {
+			Resolution Resolution
+			Error      error
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	dirs := DirsConfig{
+		Base:       "test_dir/test_templates",
+		Pages:      "test_pages",
+		Components: "test_components",
+	}
+
+	tests := []Test{
+		{
+			Name: "Given the name of a component file " +
+				"Then its path is returned",
+			Args: Args{
+				Config: Config{Dirs: dirs},
+				Name:   "badge",
+			},
+			Expected: Expected{
+				Resolution: Resolution{
+					Path: "test_dir/test_templates/test_components/badge.html.tmpl",
+					Trace: []ResolveStep{
+						{Rule: ResolveMatch, Result: "badge.html.tmpl"},
+						{Rule: ResolveRoot, Result: "test_dir/test_templates"},
+					},
+				},
+			},
+		},
+		{
+			Name: "Given a name matching a component file by its wildcards " +
+				"Then its path is returned",
+			Args: Args{
+				Config: Config{Dirs: dirs},
+				Name:   "top_dir/abc/mid_dir/123/bottom_dir/xyz",
+			},
+			Expected: Expected{
+				Resolution: Resolution{
+					Path: "test_dir/test_templates/test_components/top_dir/{param1}/mid_dir/{param2.int64}/bottom_dir/{param3}.html.tmpl",
+					Trace: []ResolveStep{
+						{Rule: ResolveMatch, Result: "top_dir/{param1}/mid_dir/{param2.int64}/bottom_dir/{param3}.html.tmpl"},
+						{Rule: ResolveRoot, Result: "test_dir/test_templates"},
+					},
+				},
+			},
+		},
+		{
+			Name: "Given a variant selected " +
+				"Then the path of the variant is returned",
+			Args: Args{
+				Config: Config{
+					Dirs: dirs,
+					VariantSelector: func(name string, props map[string]any) string {
+						return "skeleton"
+					},
+				},
+				Name: "card",
+			},
+			Expected: Expected{
+				Resolution: Resolution{
+					Path: "test_dir/test_templates/test_components/card.skeleton.html.tmpl",
+					Trace: []ResolveStep{
+						{Rule: ResolveVariant, Result: "card.skeleton"},
+						{Rule: ResolveMatch, Result: "card.skeleton.html.tmpl"},
+						{Rule: ResolveRoot, Result: "test_dir/test_templates"},
+					},
+				},
+			},
+		},
+		{
+			Name: "Given a variant selected within an overlay directory " +
+				"Then the path of the variant within the overlay directory is returned, tracing each rule applied",
+			Args: Args{
+				Config: Config{
+					Dirs:        dirs,
+					OverlayDirs: []string{"test_dir/overlay_acme"},
+					VariantSelector: func(name string, props map[string]any) string {
+						return "skeleton"
+					},
+				},
+				Name: "card",
+			},
+			Expected: Expected{
+				Resolution: Resolution{
+					Path: "test_dir/overlay_acme/test_components/card.skeleton.html.tmpl",
+					Trace: []ResolveStep{
+						{Rule: ResolveVariant, Result: "card.skeleton"},
+						{Rule: ResolveMatch, Result: "card.skeleton.html.tmpl"},
+						{Rule: ResolveOverlay, Result: "test_dir/overlay_acme"},
+					},
+				},
+			},
+		},
+		{
+			Name: "Given a variant without a file, of a component missing from the overlay directory " +
+				"Then the path of the component within the base directory is returned",
+			Args: Args{
+				Config: Config{
+					Dirs:        dirs,
+					OverlayDirs: []string{"test_dir/overlay_acme"},
+					VariantSelector: func(name string, props map[string]any) string {
+						return "missing"
+					},
+				},
+				Name: "card",
+			},
+			Expected: Expected{
+				Resolution: Resolution{
+					Path: "test_dir/test_templates/test_components/card.html.tmpl",
+					Trace: []ResolveStep{
+						{Rule: ResolveMatch, Result: "card.html.tmpl"},
+						{Rule: ResolveRoot, Result: "test_dir/test_templates"},
+					},
+				},
+			},
+		},
+		{
+			Name: "Given a disabled component " +
+				"Then an ErrComponentDisabled is returned",
+			Args: Args{
+				Config: Config{
+					Dirs:               dirs,
+					DisabledComponents: []string{"badge"},
+				},
+				Name: "badge",
+			},
+			Expected: Expected{
+				Error: &ErrComponentDisabled{Name: "badge"},
+			},
+		},
+		{
+			Name: "Given a missing component " +
+				"Then an ErrNotTemplateFileFound is returned",
+			Args: Args{
+				Config: Config{Dirs: dirs},
+				Name:   "no_such/component",
+			},
+			Expected: Expected{
+				Error: &ErrNotTemplateFileFound{
+					Dir:      "test_dir/test_templates/test_components",
+					Filename: "no_such/component.html.tmpl",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tm := new(Templater).With(test.Args.Config)
+
+			res, err := tm.ResolveComponent(test.Args.Name)
+
+			if test.Expected.Error == nil {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Resolution, res, "unexpected resolution returned")
+			} else {
+				assert.Equal(t, test.Expected.Error, err, "unexpected error returned")
+			}
+		})
+	}
+}
+
+func TestTemplater_ResolveComponent_Registered(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+	require.NoError(t, tm.RegisterComponent("badge", `<b>{{ .Label }}</b>`))
+
+	res, err := tm.ResolveComponent("badge")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, Resolution{
+		Trace: []ResolveStep{{Rule: ResolveRegistered, Result: "badge"}},
+	}, res, "expected no path for a registered component")
+}
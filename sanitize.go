@@ -0,0 +1,24 @@
+package templater
+
+import (
+	"html/template"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// sanitizeHTML is the implementation of the `sanitizeHTML` template function.
+// It returns the user provided HTML s with any elements and attributes not allowed by Config.SanitizePolicy removed,
+// eg scripts, event handlers, and javascript: links, so it's safe to output as is, eg
+//
+//	<div class="bio">{{ sanitizeHTML .User.Bio }}</div>
+func (ec *executionContext) sanitizeHTML(s string) template.HTML {
+	policy := ec.cfg.SanitizePolicy
+	if policy == nil {
+		policy = defaultSanitizePolicy()
+	}
+	return template.HTML(policy.Sanitize(s))
+}
+
+// defaultSanitizePolicy returns the policy for user generated content, permitting basic formatting, links, and images.
+var defaultSanitizePolicy = sync.OnceValue(bluemonday.UGCPolicy)
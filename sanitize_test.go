@@ -0,0 +1,31 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_SanitizeHTML(t *testing.T) {
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	}
+
+	const bio = `<b>Hi</b> <script>alert(1)</script><a href="https://example.com" onclick="steal()">site</a> <a href="javascript:alert(1)">bad</a>`
+
+	b, err := new(Templater).With(cfg).ExecuteInline(`<div>{{ sanitizeHTML .Bio }}</div>`, "Bio", bio)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<div><b>Hi</b> <a href="https://example.com" rel="nofollow">site</a> bad</div>`, string(b), "unexpected bytes returned")
+
+	cfg.SanitizePolicy = bluemonday.StrictPolicy()
+
+	b, err = new(Templater).With(cfg).ExecuteInline(`<div>{{ sanitizeHTML .Bio }}</div>`, "Bio", bio)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<div>Hi site bad</div>`, string(b), "expected the configured policy to be used")
+}
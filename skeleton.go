@@ -0,0 +1,38 @@
+package templater
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+const (
+	// skeletonVariant is the variant of a component executed by the `skeleton` template function, eg card.skeleton.html.tmpl.
+	skeletonVariant = "skeleton"
+	// skeletonPlaceholder is the output of the `skeleton` template function for components without a skeleton variant.
+	skeletonPlaceholder = `<div class="skeleton" aria-busy="true" aria-hidden="true"></div>`
+)
+
+// executeSkeleton executes the skeleton variant of the component of the given name, eg card.skeleton.html.tmpl for card,
+// to be shown in place of the component while its data loads, eg alongside `lazyComponent`.
+// Components without a skeleton variant are replaced by a generic placeholder instead.
+func (ec *executionContext) executeSkeleton(name string, props map[string]any) ([]byte, error) {
+	variantName := name + "." + skeletonVariant
+
+	if ec.caches.registeredComponent(variantName) == nil {
+		componentDir := path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Components)
+
+		match, err := findBestFilenameMatchInDir(ec.cfg.FS, variantName, ec.cfg.FileExt, componentDir)
+
+		var te *ErrNotTemplateFileFound
+		if err != nil && !errors.As(err, &te) {
+			return nil, err
+		}
+		if err != nil || !strings.HasSuffix(match, "."+skeletonVariant+ec.cfg.FileExt) {
+			// the variant doesn't exist, or only matched a wildcard
+			return []byte(skeletonPlaceholder), nil
+		}
+	}
+
+	return ec.executeComponent(variantName, props)
+}
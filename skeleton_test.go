@@ -0,0 +1,53 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_Skeleton(t *testing.T) {
+	type Test struct {
+		Name     string
+		Body     string
+		Expected string
+	}
+
+	tests := []Test{
+		{
+			Name: "Given a component with a skeleton variant " +
+				"Then the variant is executed",
+			Body:     `{{ skeleton "card" }}`,
+			Expected: "<div class=\"card skeleton\"><span class=\"skeleton-line\"></span></div>\n",
+		},
+		{
+			Name: "Given a component without a skeleton variant " +
+				"Then a generic placeholder is emitted",
+			Body:     `{{ skeleton "badge" }}`,
+			Expected: skeletonPlaceholder,
+		},
+		{
+			Name: "Given a component that doesn't exist " +
+				"Then a generic placeholder is emitted",
+			Body:     `{{ skeleton "no_such/component" }}`,
+			Expected: skeletonPlaceholder,
+		},
+	}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b, err := tm.ExecuteInline(test.Body)
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+			assert.Equal(t, test.Expected, string(b), "unexpected output")
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package templater
+
+import (
+	"fmt"
+	"strings"
+)
+
+// slotContent executes the slot of the given name like the `slot` template function,
+// except a slot the parent didn't provide content for results in empty rather than an error,
+// for components with optional named regions, eg a modal's header and footer.
+func (ec *executionContext) slotContent(name string, props map[string]any) ([]byte, error) {
+	if !hasSlot(props, name) {
+		return nil, nil
+	}
+	return ec.executeSlot(name, props)
+}
+
+// hasSlot is the implementation of the `hasSlot` template function.
+// It reports whether the parent provided content for the slot of the given name,
+// eg for a component to render default content in its place:
+//
+//	{{ if hasSlot "actions" }}{{ slot "actions" }}{{ else }}<button>Close</button>{{ end }}
+func hasSlot(props map[string]any, name string) bool {
+	_, ok := props["#"+name]
+	return ok
+}
+
+// addComponentProps is addProps for the props of a component used within another, given the props of the other.
+// The slots provided to the other, eg "#actions", aren't inherited,
+// as they're the other's alone, eg so hasSlot reports only the slots provided to the component itself.
+func addComponentProps(props map[string]any, kvs ...any) (map[string]any, error) {
+	inherited := make(map[string]any, len(props))
+	for k, v := range props {
+		if !strings.HasPrefix(k, "#") {
+			inherited[k] = v
+		}
+	}
+
+	return addProps(inherited, kvs...)
+}
+
+// checkSlotKeys returns an error if the content of a slot is provided more than once by the key-value pairs,
+// eg {{ component "modal" "#header" "a" "#header" "b" }}, rather than silently using the last.
+func checkSlotKeys(kvs ...any) error {
+	seen := make(map[string]bool)
+	for i := 0; i < len(kvs); i += 2 {
+		k, ok := kvs[i].(string)
+		if !ok || !strings.HasPrefix(k, "#") {
+			continue
+		}
+		if seen[k] {
+			return fmt.Errorf("slot %s content defined more than once", k[1:])
+		}
+		seen[k] = true
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_SlotContent(t *testing.T) {
+	type (
+		Expected struct {
+			Bytes string
+			Error bool
+		}
+		Test struct {
+			Name     string
+			Body     string
+			Expected Expected
+		}
+	)
+
+	const definitions = `{{ define "modal_header" }}<h2>{{ .Title }}</h2>{{ end }}{{ define "modal_footer" }}<button>OK</button>{{ end }}`
+
+	tests := []Test{
+		{
+			Name: "Given the header and footer slots filled " +
+				"Then both are rendered",
+			Body: definitions + `{{ component "modal" "#header" "modal_header" "#footer" "modal_footer" "Title" "Hi" "Body" "text" }}`,
+			Expected: Expected{
+				Bytes: `<div class="modal"><header><h2>Hi</h2></header><main>text</main><footer><button>OK</button></footer></div>` + "\n",
+			},
+		},
+		{
+			Name: "Given a slot not filled " +
+				"Then it's empty",
+			Body: definitions + `{{ component "modal" "#header" "modal_header" "Title" "Hi" "Body" "text" }}`,
+			Expected: Expected{
+				Bytes: `<div class="modal"><header><h2>Hi</h2></header><main>text</main><footer></footer></div>` + "\n",
+			},
+		},
+		{
+			Name: "Given a slot with default content filled " +
+				"Then the slot content is rendered",
+			Body: definitions + `{{ define "dialog_actions" }}<button>Save</button>{{ end }}{{ component "dialog" "#actions" "dialog_actions" }}`,
+			Expected: Expected{
+				Bytes: `<dialog><footer><button>Save</button></footer></dialog>` + "\n",
+			},
+		},
+		{
+			Name: "Given a slot with default content not filled " +
+				"Then the default content is rendered",
+			Body: definitions + `{{ component "dialog" "#header" "modal_header" "Title" "Hi" }}`,
+			Expected: Expected{
+				Bytes: `<dialog><h2>Hi</h2><footer><button>Close</button></footer></dialog>` + "\n",
+			},
+		},
+		{
+			Name: "Given a slot with default content not filled, within a component with that slot filled " +
+				"Then the default content is rendered",
+			Body: `{{ define "card_actions" }}<a>card action</a>{{ end }}{{ define "card_body" }}{{ component "dialog" }}{{ end }}` +
+				`{{ component "modal" "#header" "card_body" "#actions" "card_actions" }}`,
+			Expected: Expected{
+				Bytes: `<div class="modal"><header><dialog><footer><button>Close</button></footer></dialog>` + "\n" +
+					`</header><main></main><footer></footer></div>` + "\n",
+			},
+		},
+		{
+			Name: "Given a slot filled more than once " +
+				"Then an error is returned",
+			Body: definitions + `{{ component "modal" "#header" "modal_header" "#header" "modal_footer" }}`,
+			Expected: Expected{
+				Error: true,
+			},
+		},
+	}
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b, err := tm.ExecuteInline(test.Body)
+
+			if !test.Expected.Error {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Bytes, string(b), "unexpected output")
+			} else {
+				assert.Error(t, err, "expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+)
+
+// SourceMapping attributes a region of the output of ExecutePageWithSourceMap to the component producing it.
+// Start and End are the byte offsets of the region, End exclusive, and StartLine and EndLine its first and last lines,
+// numbered from 1.
+type SourceMapping struct {
+	// Component is the name the component was executed by.
+	Component string
+	// File is the path of the component file, relative to the working directory, or empty for registered components.
+	File      string
+	Start     int
+	End       int
+	StartLine int
+	EndLine   int
+}
+
+type sourceMarker struct {
+	component string
+	file      string
+}
+
+// sourceMarkerIDs generates the ids of source markers, unique across executions,
+// so markers within output cached from another execution, eg by `componentCached`, aren't mistaken for this one's.
+var sourceMarkerIDs atomic.Int64
+
+// sourceMarkerPattern matches the comments delimiting the output of components while mapping sources.
+var sourceMarkerPattern = regexp.MustCompile(`<!--templater-source:(/?)(\d+)-->`)
+
+// ExecutePageWithSourceMap is ExecutePage, additionally returning a source map attributing each region of the output
+// produced by a component to its component file, eg for debugging rendered HTML back to its templates.
+// Mappings are ordered by their start, nested components following the components containing them.
+// Offsets refer to the output before Config.PostProcessors, Config.AutoNonce, and Config.OutputEncoding are applied,
+// so are only exact without them.
+func (tm *Templater) ExecutePageWithSourceMap(name string, kvs ...any) ([]byte, []SourceMapping, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mappings []SourceMapping
+	b, err := tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		ec.render.sources = make(map[int64]sourceMarker)
+
+		b, err := ec.executePage(name, props)
+		if err != nil {
+			return nil, err
+		}
+
+		b, mappings, err = resolveSourceMarkers(b, ec.render.sources)
+		return b, err
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, mappings, nil
+}
+
+// markSource delimits the output of the component with comments identifying it, if mapping sources,
+// to be resolved into SourceMappings by resolveSourceMarkers.
+func (rs *renderState) markSource(component, file string, b []byte) []byte {
+	if rs.sources == nil {
+		return b
+	}
+
+	id := sourceMarkerIDs.Add(1)
+	rs.sources[id] = sourceMarker{
+		component: component,
+		file:      file,
+	}
+
+	marked := fmt.Appendf(nil, "<!--templater-source:%d-->", id)
+	marked = append(marked, b...)
+	return fmt.Appendf(marked, "<!--templater-source:/%d-->", id)
+}
+
+// resolveSourceMarkers removes the comments added by markSource from the output,
+// returning the mappings of the regions they delimited.
+func resolveSourceMarkers(b []byte, sources map[int64]sourceMarker) ([]byte, []SourceMapping, error) {
+	out := make([]byte, 0, len(b))
+	var mappings []SourceMapping
+	open := make(map[int64]int) // indexes of mappings by id, while open
+
+	var last int
+	for _, m := range sourceMarkerPattern.FindAllSubmatchIndex(b, -1) {
+		out = append(out, b[last:m[0]]...)
+		last = m[1]
+
+		id, err := strconv.ParseInt(string(b[m[4]:m[5]]), 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid source marker: %w", err)
+		}
+		source, ok := sources[id]
+		if !ok {
+			// not ours, eg copied into a template
+			out = append(out, b[m[0]:m[1]]...)
+			continue
+		}
+
+		if m[3] == m[2] {
+			open[id] = len(mappings)
+			mappings = append(mappings, SourceMapping{
+				Component: source.component,
+				File:      source.file,
+				Start:     len(out),
+				StartLine: bytes.Count(out, []byte("\n")) + 1,
+			})
+		} else if i, ok := open[id]; ok {
+			mappings[i].End = len(out)
+			mappings[i].EndLine = bytes.Count(out, []byte("\n")) + 1
+			delete(open, id)
+		}
+	}
+	out = append(out, b[last:]...)
+
+	return out, mappings, nil
+}
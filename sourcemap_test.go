@@ -0,0 +1,58 @@
+package templater
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecutePageWithSourceMap(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	expected, err := tm.ExecutePage("badges_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	b, mappings, err := tm.ExecutePageWithSourceMap("badges_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, string(expected), string(b), "expected the output without source markers")
+
+	require.Len(t, mappings, 2, "unexpected mappings returned: %+v", mappings)
+	for i, label := range []string{"one", "two"} {
+		m := mappings[i]
+		assert.Equal(t, "badge", m.Component, "unexpected component of mapping %d", i)
+		assert.Equal(t, "test_dir/test_templates/test_components/badge.html.tmpl", m.File, "unexpected file of mapping %d", i)
+		assert.Equal(t, `<div class="badge">`+label+"</div>\n", string(b[m.Start:m.End]), "unexpected region of mapping %d", i)
+
+		line := bytes.Count(b[:m.Start], []byte("\n")) + 1
+		assert.Equal(t, line, m.StartLine, "unexpected start line of mapping %d", i)
+		assert.Equal(t, line+1, m.EndLine, "unexpected end line of mapping %d", i)
+	}
+}
+
+func TestTemplater_ExecutePageWithSourceMap_Nested(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, mappings, err := tm.ExecutePageWithSourceMap("nested_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	require.Len(t, mappings, 2, "unexpected mappings returned: %+v", mappings)
+	outer, inner := mappings[0], mappings[1]
+	assert.Equal(t, "outer_component", outer.Component, "unexpected component of the outer mapping")
+	assert.Equal(t, "inner_component", inner.Component, "unexpected component of the inner mapping")
+	assert.True(t, outer.Start < inner.Start && inner.End < outer.End, "expected the inner region within the outer region: %+v", mappings)
+	assert.Contains(t, string(b[inner.Start:inner.End]), "AAA", "unexpected region of the inner mapping")
+}
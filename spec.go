@@ -0,0 +1,75 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"maps"
+	"strings"
+)
+
+// ComponentSpec describes a single component of a page composed from data, eg JSON, by ExecutePageFromSpec.
+type ComponentSpec struct {
+	Component string         `json:"component"`
+	Props     map[string]any `json:"props"`
+}
+
+// ExecutePageFromSpec executes the layout with the components of the spec, in order, as its "body" template.
+// Each component is given the props of kvs, overridden by the props of its spec.
+// The components are executed before the layout, so the layout's <head> may emit the items
+// stored by the components via `headItem`, with `headItems`.
+func (tm *Templater) ExecutePageFromSpec(spec []ComponentSpec, kvs ...any) ([]byte, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		return ec.executePageFromSpec(spec, props)
+	}))
+}
+
+func (ec *executionContext) executePageFromSpec(spec []ComponentSpec, props map[string]any) ([]byte, error) {
+	ec.isPage = true
+	ec.node = ec.node.add(RenderKindPage, "", props)
+
+	layout, err := ec.parseLayout("", props)
+	if err != nil {
+		return nil, err
+	}
+
+	if ec.template, err = layout.Clone(); err != nil {
+		return nil, fmt.Errorf("failed to clone layout template for component execution: %w", err)
+	}
+
+	// execute the components
+
+	var body strings.Builder
+	for i, s := range spec {
+		cprops := maps.Clone(props)
+		maps.Copy(cprops, s.Props)
+
+		b, err := ec.executeComponent(s.Component, cprops)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute component %d of spec: %w", i, err)
+		}
+		body.Write(ec.cfg.trimComponentOutput(b))
+	}
+
+	// define "body" template
+
+	if _, err := layout.Funcs(template.FuncMap{
+		"specBody": func() template.HTML {
+			return template.HTML(body.String())
+		},
+	}).New("body").Parse("{{ specBody }}"); err != nil {
+		return nil, fmt.Errorf("failed to parse body html template: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := ec.render.executeLayout(buf, layout, props); err != nil {
+		return nil, fmt.Errorf("failed to execute html template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,54 @@
+package templater
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecutePageFromSpec(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	var spec []ComponentSpec
+	err := json.Unmarshal([]byte(`[
+		{"component": "hero", "props": {"Title": "Welcome"}},
+		{"component": "cta", "props": {"Label": "Sign up", "Href": "/signup"}}
+	]`), &spec)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	b, err := tm.ExecutePageFromSpec(spec, "Site", "Pets")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	expected := `<!DOCTYPE html>
+<html>
+	<head>
+		<title>ABC</title><link rel="stylesheet" href="/hero.css"><script src="/cta.js"></script>
+	</head>
+	<body>
+		<header>
+			HEAD
+		</header><section class="hero">Welcome - Pets</section>
+<a class="cta" href="/signup">Sign up</a>
+
+
+		<footer>
+			FOOTER
+		</footer>
+	</body>
+</html>
+`
+	assert.Equal(t, expected, string(b), "unexpected bytes returned")
+
+	_, err = tm.ExecutePageFromSpec([]ComponentSpec{{Component: "no_such/component"}})
+
+	var nfErr *ErrNotTemplateFileFound
+	assert.ErrorAs(t, err, &nfErr, "expected an ErrNotTemplateFileFound")
+}
@@ -0,0 +1,64 @@
+package templater
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/angelbeltran/templater/funcs"
+)
+
+// componentState is the name and props of a component executed while Config.CollectState is set.
+type componentState struct {
+	Name  string                     `json:"name"`
+	Props map[string]json.RawMessage `json:"props"`
+}
+
+// collectState records the name and props of the component for the page's state blob.
+// The props are those provided where the component is used, rather than every prop it inherits,
+// eg server-only props of the page. They're serialized as they are when it's executed.
+// Any that can't be, eg funcs, are omitted.
+func (rs *renderState) collectState(name string, props map[string]any) {
+	state := componentState{
+		Name:  name,
+		Props: make(map[string]json.RawMessage, len(props)),
+	}
+	for k, v := range props {
+		if b, err := json.Marshal(v); err == nil {
+			state.Props[k] = b
+		}
+	}
+
+	rs.state = append(rs.state, state)
+}
+
+// emitState inserts a script assigning the name and props of each component executed, in order,
+// to window.__STATE__ at the end of the page's <body>, eg for client-side hydration.
+func (rs *renderState) emitState(page []byte) ([]byte, error) {
+	state := rs.state
+	if state == nil {
+		state = []componentState{}
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize page state: %w", err)
+	}
+
+	return insertBefore(page, "</body>", "<script>window.__STATE__ = "+string(b)+";</script>\n"), nil
+}
+
+// addCallProps is addComponentProps, additionally recording the props provided by kvs
+// as those of the next component executed, for Config.CollectState.
+func (ec *executionContext) addCallProps(props map[string]any, kvs ...any) (map[string]any, error) {
+	cpy, err := addComponentProps(props, kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if ec.cfg.CollectState {
+		// addComponentProps has checked the kvs
+		ec.callProps, _ = funcs.NewKVSProps(kvs...)
+	}
+
+	return cpy, nil
+}
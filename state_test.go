@@ -0,0 +1,56 @@
+package templater
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_CollectState(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		CollectState: true,
+	})
+
+	b, err := tm.ExecutePage("state_page", "Handler", func() {}, "Secret", "s3cret")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	blobs := regexp.MustCompile(`<script>window.__STATE__ = (.*);</script>\n</body>`).FindAllSubmatch(b, -1)
+	require.Len(t, blobs, 1, "expected a single state blob at the end of the body: %s", b)
+
+	var state []struct {
+		Name  string         `json:"name"`
+		Props map[string]any `json:"props"`
+	}
+	require.NoError(t, json.Unmarshal(blobs[0][1], &state), "invalid state blob: %s", blobs[0][1])
+	require.Len(t, state, 2, "unexpected state: %s", blobs[0][1])
+
+	assert.Equal(t, "badge", state[0].Name, "unexpected component name")
+	assert.Equal(t, "one", state[0].Props["Label"], "unexpected component props")
+	assert.NotContains(t, state[0].Props, "OnClick", "expected props that can't be serialized to be omitted")
+	assert.NotContains(t, state[0].Props, "Secret", "expected props inherited from the page to be omitted")
+
+	assert.Equal(t, "card", state[1].Name, "unexpected component name")
+	assert.Equal(t, map[string]any{"Title": "Two"}, state[1].Props, "expected only the props provided where the component is used")
+}
+
+func TestTemplater_CollectState_Disabled(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecutePage("state_page", "Handler", func() {})
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.NotContains(t, string(b), "__STATE__", "unexpected state blob")
+}
@@ -0,0 +1,85 @@
+package templater
+
+import "maps"
+
+// renderState is the state of a single top-level execution, shared by all of its contexts.
+type renderState struct {
+	// store holds the values stored by the `store` template function, by key.
+	store map[string][]any
+	// stored holds the values stored during the first pass of a two pass execution, by key.
+	// It's nil otherwise.
+	stored map[string][]any
+	// ids holds the number of ids generated by the `uniqueID` template function, by prefix.
+	ids map[string]int
+	// styles and scripts hold the contents of the component assets collected when Config.BundleAssets is set,
+	// in order of first use. assets holds the paths of those collected.
+	styles, scripts []string
+	assets          map[string]bool
+	// outputs holds the output of components used via `componentCached`, by key.
+	outputs map[string][]byte
+	// renders and funcCalls count the components and slots executed, and the template functions called,
+	// for Config.MaxRenders and Config.MaxFuncCalls.
+	renders, funcCalls int
+	// deferHeadItems is set while executing a layout, for the `headItems` template function to emit a placeholder
+	// filled once the whole page has been executed.
+	deferHeadItems bool
+	// impureCalls counts the calls of impureFuncs, for componentCached.
+	impureCalls int
+	// instances counts the executions of each component by name, for Config.MaxComponentInstances.
+	instances map[string]int
+	// timings holds the durations of the render phases of pages, for ExecutePageWithTimings.
+	timings []ServerTiming
+	// state holds the components executed while Config.CollectState is set, in order.
+	state []componentState
+	// sources holds the components whose output is delimited by markSource, by id, when mapping sources.
+	// It's nil otherwise.
+	sources map[int64]sourceMarker
+}
+
+func newRenderState() *renderState {
+	return &renderState{
+		store:     make(map[string][]any),
+		ids:       make(map[string]int),
+		assets:    make(map[string]bool),
+		outputs:   make(map[string][]byte),
+		instances: make(map[string]int),
+	}
+}
+
+// storeValue is the implementation of the `store` template function.
+// It appends the value to those stored under the key for the remainder of the execution,
+// eg to collect footnotes from the components of a page, and outputs nothing.
+func (rs *renderState) storeValue(key string, value any) string {
+	rs.store[key] = append(rs.store[key], value)
+	return ""
+}
+
+// retrieve is the implementation of the `retrieve` template function.
+// It returns the values stored under the key so far, in the order stored.
+// As templates execute top to bottom, only values stored by templates executed earlier are retrieved,
+// eg a layout's footer may list the footnotes stored within the page body, but its header may not.
+// In the second pass of a two pass execution, every value stored during the first pass is returned instead.
+func (rs *renderState) retrieve(key string) []any {
+	if rs.stored != nil {
+		return rs.stored[key]
+	}
+	return rs.store[key]
+}
+
+// executePasses executes fn once, or twice if Config.TwoPass is set,
+// providing the values stored during the first pass to the second.
+func (tm *Templater) executePasses(props map[string]any, fn func(ec *executionContext, props map[string]any) ([]byte, error)) ([]byte, error) {
+	if !tm.cfg.TwoPass {
+		return fn(tm.newContext(), props)
+	}
+
+	first := tm.newContext()
+	if _, err := fn(first, maps.Clone(props)); err != nil {
+		return nil, err
+	}
+
+	second := tm.newContext()
+	second.render.stored = first.render.store
+
+	return fn(second, props)
+}
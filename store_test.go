@@ -0,0 +1,96 @@
+package templater
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_Store(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInlinePage(
+		`<main>{{ block "body" . }}{{ end }}</main><footer>{{ range retrieve "footnotes" }}<small>{{ . }}</small>{{ end }}</footer>`,
+		"",
+		`{{ component "footnoted" "Text" "first" "Note" "a" }}{{ component "footnoted" "Text" "second" "Note" "b" }}`,
+	)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<main><p>first<sup>1</sup></p>`+"\n"+`<p>second<sup>2</sup></p>`+"\n"+`</main>`+
+		`<footer><small>a</small><small>b</small></footer>`, string(b), "unexpected bytes returned")
+
+	b, err = tm.ExecuteComponent("footnoted", "Text", "third", "Note", "c")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Contains(t, string(b), "<sup>1</sup>", "expected the store to be scoped to a single execution")
+}
+
+func TestTemplater_TwoPass(t *testing.T) {
+	const (
+		layout = `<nav>{{ range retrieve "toc" }}<a>{{ . }}</a>{{ end }}</nav><main>{{ block "body" . }}{{ end }}</main>`
+		body   = `{{ component "heading" "Title" "Intro" }}{{ component "heading" "Title" "Usage" }}`
+	)
+
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	}
+
+	b, err := new(Templater).With(cfg).ExecuteInlinePage(layout, "", body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Contains(t, string(b), "<nav></nav>", "expected a single pass not to retrieve values stored later")
+
+	cfg.TwoPass = true
+
+	b, err = new(Templater).With(cfg).ExecuteInlinePage(layout, "", body)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<nav><a>Intro</a><a>Usage</a></nav><main><h2>Intro</h2>`+"\n"+`<h2>Usage</h2>`+"\n"+`</main>`, string(b), "unexpected bytes returned")
+}
+
+func TestTemplater_TwoPassEntryPoints(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		TwoPass: true,
+	})
+
+	const expected = `<nav><a>Intro</a></nav>`
+
+	t.Run("Given Execute "+
+		"Then the page is executed in two passes", func(t *testing.T) {
+		b, err := tm.Execute("toc_page")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Contains(t, string(b), expected, "expected the values stored by the first pass to be retrieved")
+	})
+
+	t.Run("Given RenderToResponse "+
+		"Then the page is executed in two passes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		require.NoError(t, tm.RenderToResponse(w, httptest.NewRequest(http.MethodGet, "/toc_page", nil), "toc_page"))
+		assert.Contains(t, w.Body.String(), expected, "expected the values stored by the first pass to be retrieved")
+	})
+
+	t.Run("Given RenderNegotiated "+
+		"Then the page is executed in two passes", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/toc_page", nil)
+		r.Header.Set("Accept", "text/html")
+
+		w := httptest.NewRecorder()
+		require.NoError(t, tm.RenderNegotiated(w, r, "toc_page"))
+		assert.Contains(t, w.Body.String(), expected, "expected the values stored by the first pass to be retrieved")
+	})
+}
@@ -0,0 +1,107 @@
+package templater
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sync"
+)
+
+// Stream is a prop of values produced during execution, eg from a database cursor,
+// rather than held in memory beforehand. Templates range over its values with the All method, eg
+//
+//	{{ range .Rows.All }}<tr><td>{{ .Name }}</td></tr>{{ end }}
+//
+// As its values are not retained, a Stream may be ranged over only once,
+// All returning an error thereafter.
+type Stream struct {
+	mu   sync.Mutex
+	seq  iter.Seq[any]
+	used bool
+	err  error
+}
+
+// NewStream returns a Stream of the values of seq.
+func NewStream[T any](seq iter.Seq[T]) *Stream {
+	return &Stream{
+		seq: func(yield func(any) bool) {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// NewChanStream returns a Stream of the values received from ch until it's closed.
+func NewChanStream[T any](ch <-chan T) *Stream {
+	return NewStream(func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// NewJSONStream returns a Stream of the elements of the JSON array read from r,
+// decoding each element as it's ranged over.
+// Any error reading or decoding r ends the Stream, and is returned by its Err method.
+func NewJSONStream(r io.Reader) *Stream {
+	s := new(Stream)
+	s.seq = func(yield func(any) bool) {
+		dec := json.NewDecoder(r)
+
+		if tok, err := dec.Token(); err != nil {
+			s.setErr(fmt.Errorf("failed to read json stream: %w", err))
+			return
+		} else if tok != json.Delim('[') {
+			s.setErr(fmt.Errorf("expected a json array: received %v", tok))
+			return
+		}
+
+		for dec.More() {
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				s.setErr(fmt.Errorf("failed to decode json stream element: %w", err))
+				return
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	return s
+}
+
+// All returns the values of the stream for ranging over.
+// It returns an error if the stream has already been ranged over.
+func (s *Stream) All() (iter.Seq[any], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.used {
+		return nil, errors.New("stream has already been ranged over: streams may only be ranged over once")
+	}
+	s.used = true
+
+	return s.seq, nil
+}
+
+// Err returns the error that ended the stream early, if any.
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+func (s *Stream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
@@ -0,0 +1,53 @@
+package templater
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	type Row struct {
+		Name string
+	}
+
+	rows := func() *Stream {
+		ch := make(chan Row)
+		go func() {
+			defer close(ch)
+			for _, name := range []string{"Ann", "Bob", "Cat"} {
+				ch <- Row{Name: name}
+			}
+		}()
+		return NewChanStream(ch)
+	}
+
+	b, err := tm.ExecuteInline(`<table>{{ range .Rows.All }}<tr><td>{{ .Name }}</td></tr>{{ end }}</table>`, "Rows", rows())
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<table><tr><td>Ann</td></tr><tr><td>Bob</td></tr><tr><td>Cat</td></tr></table>`, string(b), "unexpected bytes returned")
+
+	_, err = tm.ExecuteInline(`{{ range .Rows.All }}{{ end }}{{ range .Rows.All }}{{ end }}`, "Rows", rows())
+	assert.ErrorContains(t, err, "only be ranged over once", "expected an error ranging over a stream twice")
+
+	s := NewJSONStream(strings.NewReader(`[{"Name": "Ann"}, {"Name": "Bob"}]`))
+	b, err = tm.ExecuteInline(`{{ range .Rows.All }}<li>{{ .Name }}</li>{{ end }}`, "Rows", s)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	require.NoError(t, s.Err(), "unexpected stream error")
+	assert.Equal(t, `<li>Ann</li><li>Bob</li>`, string(b), "unexpected bytes returned")
+
+	s = NewJSONStream(strings.NewReader(`[{"Name": "Ann"}, {"Name": `))
+	b, err = tm.ExecuteInline(`{{ range .Rows.All }}<li>{{ .Name }}</li>{{ end }}`, "Rows", s)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Error(t, s.Err(), "expected the stream to end with an error")
+	assert.Equal(t, `<li>Ann</li>`, string(b), "unexpected bytes returned")
+}
@@ -0,0 +1,113 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/angelbeltran/templater/funcs"
+)
+
+// svg is the implementation of the `svg` template function.
+// It returns the contents of the SVG file of the given name in the icons directory,
+// with the attribute key-value pairs set on the root <svg> element.
+// File contents are cached, so changes to icons require a new Templater.
+func (ec *executionContext) svg(name string, attrs ...any) (template.HTML, error) {
+	overrides, err := funcs.NewKVSProps(attrs...)
+	if err != nil {
+		return "", err
+	}
+
+	if !fs.ValidPath(name) || strings.Contains(name, "\\") {
+		return "", fmt.Errorf("invalid svg name: %q", name)
+	}
+
+	filename := path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Icons, name+".svg")
+
+	var src []byte
+	if v, ok := ec.caches.svgs.Load(filename); ok {
+		src = v.([]byte)
+	} else {
+		if src, err = fs.ReadFile(ec.cfg.FS, filename); err != nil {
+			return "", fmt.Errorf("failed to read svg %s: %w", name, err)
+		}
+		ec.caches.svgs.Store(filename, src)
+	}
+
+	out, err := setRootSVGAttrs(src, overrides)
+	if err != nil {
+		return "", fmt.Errorf("invalid svg %s: %w", name, err)
+	}
+
+	return template.HTML(out), nil
+}
+
+// setRootSVGAttrs returns the svg document starting from its root <svg> element,
+// omitting any preceding xml declaration or comments, with the given attributes set on the root element.
+// The attribute names are validated as by attrEscape, as their values are only escaped as text.
+func setRootSVGAttrs(src []byte, attrs map[string]any) ([]byte, error) {
+	for k := range attrs {
+		if err := funcs.ValidateAttrName(k); err != nil {
+			return nil, err
+		}
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(src))
+
+	var offset int
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return nil, fmt.Errorf("no root <svg> element found")
+		}
+
+		raw := len(z.Raw())
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			offset += raw
+			continue
+		}
+
+		tag := z.Raw()
+		if name, _ := z.TagName(); atom.Lookup(name) != atom.Svg {
+			return nil, fmt.Errorf("expected the root element to be <svg>: found <%s>", name)
+		}
+
+		end := ">"
+		if tt == html.SelfClosingTagToken {
+			end = "/>"
+		}
+
+		overridden := make(map[string]bool, len(attrs))
+		for k := range attrs {
+			overridden[strings.ToLower(k)] = true
+		}
+
+		var b bytes.Buffer
+		b.WriteString("<svg")
+		// attributes are copied from the raw tag, as the tokenizer lowercases their names, eg viewBox
+		for _, m := range svgAttrPattern.FindAllSubmatch(tag[len("<svg"):], -1) {
+			if !overridden[strings.ToLower(string(m[2]))] {
+				b.Write(m[1])
+			}
+		}
+		for _, k := range slices.Sorted(maps.Keys(attrs)) {
+			fmt.Fprintf(&b, ` %s="%s"`, k, template.HTMLEscapeString(fmt.Sprint(attrs[k])))
+		}
+		b.WriteString(end)
+		b.Write(src[offset+raw:])
+
+		return b.Bytes(), nil
+	}
+}
+
+// svgAttrPattern matches an attribute of a start tag, capturing the whitespace prefixed attribute and its name.
+var svgAttrPattern = regexp.MustCompile(`(\s+([^\s=/>]+)(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s>]+))?)`)
@@ -0,0 +1,43 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_SVG(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInline(`{{ svg "star" "class" "icon icon-star" "width" 16 }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" class="icon icon-star" width="16">`+
+		`<path d="M12 2l3 7h7l-6 4 2 7-6-4-6 4 2-7-6-4h7z"/></svg>`+"\n", string(b), "unexpected bytes returned")
+
+	_, err = tm.ExecuteInline(`{{ svg "../layout.html" }}`)
+	assert.ErrorContains(t, err, "invalid svg name", "expected path traversal to be rejected")
+
+	b, err = tm.ExecuteInline(`{{ svg "star" "title" .Title }}`, "Title", `"><script>alert(1)</script>`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Contains(t, string(b), ` title="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;">`, "expected the attribute value to be escaped")
+
+	_, err = tm.ExecuteInline(`{{ svg "star" "onload" "alert(1)" }}`)
+	assert.ErrorContains(t, err, `attribute "onload" is not allowed`, "expected an event handler attribute to be rejected")
+
+	_, err = tm.ExecuteInline(`{{ svg "star" "xlink:href" "javascript:alert(1)" }}`)
+	assert.ErrorContains(t, err, `attribute "xlink:href" is not allowed`, "expected a url attribute to be rejected")
+
+	_, err = tm.ExecuteInline(`{{ svg "star" "class=\"x\" onload=\"alert(1)" "" }}`)
+	assert.ErrorContains(t, err, "invalid attribute name", "expected an attribute name breaking out of the quotes to be rejected")
+
+	_, err = tm.ExecuteInline(`{{ svg "star" "x><script>alert(1)</script" "" }}`)
+	assert.ErrorContains(t, err, "invalid attribute name", "expected an attribute name breaking out of the tag to be rejected")
+}
@@ -56,7 +56,59 @@
 // templates into larger components and webpages in a manner that is more modular.
 //
 // Additional template functions provided are
+// - componentSafe: uses a component, or if it fails, a fallback given an "error" prop, eg {{ componentSafe "chart" "chart-error" }}.
+// - componentCached: uses a pure component, reusing its output when used again with identical props, eg a site footer.
+// - slotContent: like slot, uses the content provided for a named slot, eg "#header" "modal_header", or nothing if none was.
+// - hasSlot: reports whether content was provided for a named slot, eg to use default content otherwise.
+// - lazyComponent: emits a placeholder naming a component and its props, for the client to load later.
+// - ifVisible: like lazyComponent, for loading once scrolled into view, containing the component within <noscript>.
+// - skeleton: uses the skeleton variant of a component, eg card.skeleton.html.tmpl, or a generic placeholder, while it loads.
 // - props: constructs a props map[string]any in the many used by component.
+// - nestedProps: like props, but dotted keys construct nested props, eg {{ nestedProps "user.name" "Ann" }}.
+// - deepMerge: merges props recursively, replacing or appending slices, eg {{ deepMerge "append" .Defaults .Overrides }}.
+// - safeURLStrict: validates user provided links, permitting only http, https, mailto, and relative URLs.
+// - sanitizeHTML: removes the elements and attributes of user provided HTML not allowed by Config.SanitizePolicy.
+// - isFirst, isLast, withIndex: report the position of a range's index, or pair each element with its index and position.
+// - urlJoin: joins URL path segments with single slashes, escaping each and rejecting dot segments, eg {{ urlJoin "/pets/" .ID }}.
+// - isEmbedded: reports whether the template is being executed within a page, rather than standalone.
+// - image: emits a responsive <img>, as image src alt widths..., with the required alt text and a srcset of the given widths, eg {{ image "/cat.jpg" "A cat" 320 640 }}.
+// - lqip: returns a tiny placeholder data URI of an image in the images directory, eg <img src="{{ lqip "cat.jpg" }}">.
+// - qrcode: returns an inline SVG QR code of content, of the given size in pixels, eg {{ qrcode .URL 160 }}.
+// - svg: inlines the SVG file of the given name from the icons directory, with the given attributes, validated as by attrEscape, set on its root element, eg {{ svg "star" "class" "icon" }}.
+// - table: emits a <table> from a slice of structs or maps, eg {{ table .Users "Name" "FullName" }}.
+// - sortHeader: emits a link sorting a table by a field, toggling its direction, preserving the WithQuery parameters.
+// - dataAttrs: emits data-* attributes from a map, eg <div {{ dataAttrs .Data }}>.
+// - aria, ariaExpanded, ariaHidden, ariaControls: emit ARIA attributes, omitting those not applicable, eg <button {{ ariaExpanded .Open }}>.
+// - contrastColor: returns black or white, whichever is most readable on a background color, eg {{ contrastColor .Color }}.
+// - colorFromString: returns a stable color derived from a string, eg for tags, from Config.ColorPalette if set.
+// - bem: constructs BEM class names, eg {{ bem "card" "title" "large" }}.
+// - gridClasses: constructs responsive grid utility classes, eg {{ gridClasses 2 "4" (props "md" 4) }}.
+// - fieldError, hasError: return a form field's validation error message from a map, or report whether it has one.
+// - jsEscape, cssEscape: explicitly escape a value for a JS or CSS context.
+// - attrEscape: emits a whole attribute, as attrEscape name value, of a dynamic but validated name with its value escaped, eg <div {{ attrEscape .Name .Value }}>.
+// - formatPhone, formatPattern: format phone numbers by region, or digits by a pattern, eg "(###) ###-####".
+// - dateIn: formats a time in the named time zone, eg {{ dateIn "3:04 PM" .CreatedAt "America/New_York" }}.
+// - rolloutEnabled: reports whether a key, eg a user id, is within the percentage rollout of a flag, eg {{ if rolloutEnabled "new-nav" .UserID }}.
+// - plural, pluralize: select or construct the plural form of a word for a count, eg {{ pluralize .Count "item" }}.
+// - ordinal: returns a number with its English ordinal suffix, eg {{ ordinal .Rank }} results in 1st, 2nd, or 23rd.
+// - buildInfo: returns the BuildInfo of the application, eg {{ buildInfo.Commit }}.
+// - tableOfContents, headingIDs: list links to the h2 and h3 headings of content, and assign the ids linked to.
+// - diffHTML: emits the words of a new text, wrapping those removed from the old in <del>, and those added in <ins>.
+// - truncateHTML: truncates HTML content to its first n characters of text, closing any elements left open.
+// - readingTime: estimates the minutes needed to read text or HTML content.
+// - header: returns a request header provided by WithHeaders, if listed by Config.AllowedHeaders, eg {{ header "Accept-Language" }}.
+// - withConsent: reports whether a consent category was granted via WithConsent, eg {{ if withConsent "analytics" }}.
+// - cspNonce: returns the CSP nonce provided by WithNonce, eg <script nonce="{{ cspNonce }}">.
+// - experiment: returns the variant of an experiment assigned via WithExperiments, eg {{ if eq (experiment "nav") "treatment" }}.
+// - uniqueID, refID: generate an id unique to the execution, and reference it again, eg <label for="{{ refID "field" }}">.
+// - frame: registers an element id as that of a fragment reported by ExecutePageWithFrames, eg <div id="{{ frame "cart" }}">.
+// - headItem, headItems: store <head> content with a priority, and emit it sorted by priority, eg charset first.
+// - ogImage: stores the og:image meta tags of an image in the images directory, with its dimensions, as a head item.
+// - hreflangs: stores the alternate links of a path in each of Config.Locales, x-default included, as a head item.
+// - breadcrumbsLD: stores a JSON-LD BreadcrumbList of the page's path as a head item, eg {{ breadcrumbsLD "https://example.com" }}.
+// - store, retrieve: append a value under a key, and retrieve the values stored earlier in the execution, eg footnotes.
+// - sortStrings, sortedKeys: sort strings, or the keys of a map, for Config.Locale, eg {{ range sortedKeys .Cities }}.
+// - load: calls the named loader of Config.DataLoaders with the current props, eg {{ $user := load "user" }}.
 //
 // Additionally, path wildcards of the form {.*} are supported.
 // For example, given a component file /component/buttons/{id}/id-button.html.tmpl
@@ -71,51 +123,285 @@
 //		  123
 //	 </button>
 //
+// Wildcards may declare a type, eg {id.int}, in which case the path parameter is parsed as that type,
+// and execution fails with an *ErrInvalidWildcardValue if it can't be.
+// Supported types are bool, string, byte (hexadecimal), rune, and the int, uint, float, and complex types.
+//
 // Similar behavior is provided in ExecutePage.
 package templater
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
 	"maps"
-	"os"
+	"net/http"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/microcosm-cc/bluemonday"
 
 	"github.com/angelbeltran/templater/funcs"
 )
 
 type (
+	// Templater executes the templates of its Config.
+	// Once configured via With, a Templater, and the copies returned by its With* methods,
+	// may be used by many goroutines at once, eg by every request handler of a server.
+	// Each execution has its own state, eg the values stored by `store` and `headItem`,
+	// and the caches they share are safe for concurrent use.
+	// With itself reconfigures the Templater, so must not be called while it's in use.
+	// The functions of Config, eg Funcs, DataLoaders, and the render hooks, are called concurrently,
+	// so must be safe for concurrent use themselves.
 	Templater struct {
 		cfg Config
+		// query holds the props parsed from a query string by WithQuery.
+		query map[string]any
+		// rawQuery holds the query string provided to WithQuery.
+		rawQuery string
+		// headers holds the request headers provided by WithHeaders.
+		headers http.Header
+		// consent holds the consent categories granted via WithConsent.
+		consent []string
+		// nonce holds the CSP nonce provided by WithNonce.
+		nonce string
+		// experiments holds the experiment variants assigned via WithExperiments, by experiment name.
+		experiments map[string]string
+		// ctx is the context provided to ExecutePageContext or ExecuteComponentContext.
+		ctx context.Context
+		// poller polls for template changes when Config.PollInterval is set.
+		poller *poller
+		// caches are shared by the Templater and its copies.
+		caches *caches
 	}
 
 	Config struct {
-		Funcs   func(name string, props map[string]any) template.FuncMap
-		Dirs    DirsConfig
-		FileExt string
+		Funcs func(name string, props map[string]any) template.FuncMap
+		Dirs  DirsConfig
+		// FS is the filesystem the template files, and the assets, icons, and images alongside them, are read from,
+		// Dirs.Base being a path within it, eg an embed.FS, or an fstest.MapFS in tests.
+		// Defaults to the operating system's filesystem, Dirs.Base being absolute or relative to the working directory.
+		FS fs.FS
+		// OverlayDirs are directories structured as Dirs.Base, eg of a theme or tenant, whose files shadow
+		// those at the same paths within Dirs.Base, so a few templates may be customised without copying them all.
+		// Each file is resolved from the first overlay directory having it, otherwise from Dirs.Base.
+		// As with Dirs.Base, they're paths of FS.
+		OverlayDirs []string
+		FileExt     string
+		// URLPolicy configures the URLs accepted by the `safeURLStrict` template function.
+		URLPolicy funcs.URLPolicy
+		// DisabledComponents lists components that may not be executed,
+		// eg unfinished components not yet ready for production.
+		// Executing one results in an ErrComponentDisabled.
+		DisabledComponents []string
+		// RawComponents lists components executed with text/template rather than html/template,
+		// so their output is not escaped, eg author controlled components with complex inline SVG or JS.
+		// Any value they output is emitted as is, so they must never output user input,
+		// and the templates of the layout or page using them, eg slot content, are not available to them.
+		RawComponents []string
+		// Images configures the markup emitted by the `image` template function, called as image src alt widths...
+		Images funcs.ImageConfig
+		// TrimComponentOutput trims the leading and trailing whitespace of the output
+		// of each component and slot before inserting it into its parent.
+		// Output containing a <pre> or <textarea> element is left untouched, as is the top-level output.
+		// Components used within a parent's <pre> element should not rely on this setting.
+		TrimComponentOutput bool
+		// VariantSelector selects the variant of the page or component to execute, eg for A/B testing.
+		// Given a name "home" and a selected variant "B", the file home.B.html.tmpl is executed if it exists,
+		// otherwise home.html.tmpl is. An empty variant selects the file of the name itself.
+		VariantSelector func(name string, props map[string]any) string
+		// DataLoaders are the loaders available to the `load` template function, by name.
+		// Each is called with the props of the template calling `load`.
+		DataLoaders map[string]func(props map[string]any) (any, error)
+		// Plurals is a catalog of plural forms by singular form used by the `pluralize` template function,
+		// eg for irregular or non-English words.
+		Plurals map[string]string
+		// WordsPerMinute is the reading speed assumed by the `readingTime` template function. Defaults to 200.
+		WordsPerMinute int
+		// Rollouts are the percentages, 0 to 100, of keys for which each flag is enabled,
+		// as reported by the `rolloutEnabled` template function. Flags not listed are disabled.
+		Rollouts map[string]float64
+		// BuildInfo is provided to templates by the `buildInfo` template function.
+		// Defaults to the build information embedded in the running binary.
+		BuildInfo *BuildInfo
+		// TwoPass executes pages twice, the first pass populating the values stored by the `store` template function,
+		// so `retrieve` returns every value stored during the page's execution in the second pass,
+		// even those stored later in the page, eg a table of contents preceding its headings.
+		// The output of the first pass is discarded, but any side effects of template functions are not,
+		// so template functions should be idempotent when enabled.
+		TwoPass bool
+		// PollInterval, when set, polls the template files for changes at the given interval,
+		// until the Templater is closed. It's a portable alternative to filesystem notifications,
+		// eg for network filesystems.
+		PollInterval time.Duration
+		// OnTemplateChange is called with the path, relative to Dirs.Base,
+		// of each template file added, modified, or removed, as detected by polling.
+		OnTemplateChange func(name string)
+		// MaxDepth limits how deeply components and slots may be nested within a single execution,
+		// guarding against unbounded recursion, eg a component using itself with ever changing props.
+		// Defaults to 100.
+		MaxDepth int
+		// MaxRenders, if set, limits the number of components and slots executed within a single execution,
+		// eg guarding against huge ranges of components in untrusted templates.
+		// Exceeding it aborts the execution with an ErrLimitExceeded.
+		MaxRenders int
+		// MaxFuncCalls, if set, limits the number of template function calls within a single execution,
+		// components and slots included. Exceeding it aborts the execution with an ErrLimitExceeded.
+		MaxFuncCalls int
+		// MaxComponentInstances, if set, limits the number of times any one component may be executed within
+		// a single execution, eg catching a misconfigured list rendering a component thousands of times.
+		// Exceeding it aborts the execution with an ErrLimitExceeded.
+		MaxComponentInstances int
+		// CheckDuplicateIDs parses the output of each execution for id attributes used more than once,
+		// returning an ErrDuplicateIDs listing them, eg when a component emitting a fixed id is used twice.
+		CheckDuplicateIDs bool
+		// BeforeRender, if set, is called before each page, component, and slot is executed,
+		// with its kind, one of the RenderKind constants, its name, and its props.
+		BeforeRender func(kind, name string, props map[string]any)
+		// AfterRender, if set, is called after each page, component, and slot is executed,
+		// with its kind, name, output, and error. The output and error it returns replace them,
+		// eg to wrap or transform the output, so it should return them as is otherwise.
+		AfterRender func(kind, name string, b []byte, err error) ([]byte, error)
+		// AllowedHeaders lists the request headers the `header` template function may return.
+		// Any other header is returned as empty, so sensitive headers, eg Cookie, are not exposed to templates.
+		AllowedHeaders []string
+		// BundleAssets includes the CSS and JS files alongside each component file used by a page, if any,
+		// eg card.css and card.js for card.html.tmpl, in the page's output.
+		// Stylesheets are inlined at the end of its <head>, and scripts at the end of its <body>,
+		// each once, in the order their components were first used.
+		BundleAssets bool
+		// OutputEncoding, if set, encodes the output of each execution in the named character encoding, eg windows-1252,
+		// rather than UTF-8, updating the charset of any <meta charset> tag to match.
+		// Characters the encoding cannot represent are replaced with numeric character references, eg &#8364;.
+		OutputEncoding string
+		// ComponentCacheTTL, if set, keeps the output of components used via `componentCached`
+		// for the given duration across executions. Otherwise it's kept for the remainder of the execution.
+		// The output of components with side effects, or depending on the request, eg via the CSP nonce, is never kept.
+		ComponentCacheTTL time.Duration
+		// Locale is the BCP 47 language tag of the locale the `sortStrings` and `sortedKeys` template functions sort for, eg "de".
+		Locale string
+		// SanitizePolicy is the allowlist of elements and attributes of the `sanitizeHTML` template function.
+		// Defaults to bluemonday.UGCPolicy, permitting basic formatting, links, and images.
+		SanitizePolicy *bluemonday.Policy
+		// AutoNonce adds the CSP nonce provided by WithNonce to each inline <script> and <style> of the output
+		// without one, so templates needn't use `cspNonce` themselves. Scripts with a src are left as is.
+		AutoNonce bool
+		// PostProcessors are applied in order to the parsed output of each execution before it's serialized,
+		// eg to add heading anchors, or footnote backlinks, without each template doing so itself.
+		PostProcessors []PostProcessor
+		// MissingComponentBehavior determines the output of the `component` template function when no component
+		// file matches its name: one of MissingComponentError, MissingComponentPlaceholder, or MissingComponentOmit.
+		// Defaults to MissingComponentError, failing the execution.
+		MissingComponentBehavior string
+		// ColorPalette, if set, is the hex colors the `colorFromString` template function selects from,
+		// eg brand colors. Otherwise it derives colors from the full range of hues.
+		ColorPalette []string
+		// CollectState collects the name and props of each component executed by a page, those provided where it's used,
+		// and emits them as a JSON array assigned to window.__STATE__ in a script at the end of the page's <body>,
+		// eg for client-side hydration, rather than each component emitting its own. Props that can't be
+		// serialized as JSON, eg funcs, are omitted.
+		CollectState bool
+		// ImagesURL is the URL the files of the images directory are served from, eg https://example.com/images,
+		// for the `ogImage` template function. Defaults to Dirs.Images at the root, eg /images.
+		ImagesURL string
+		// GlobalPropsProvider, if set, computes props for each execution via ExecutePageContext or ExecuteComponentContext from its context,
+		// eg the current user or live feature flags of the request. Props provided at execution take precedence.
+		// An error returned aborts the execution.
+		GlobalPropsProvider func(ctx context.Context) (map[string]any, error)
+		// ContextFuncs, if set, builds template functions as Funcs does, given the context of the execution,
+		// eg to read request-scoped values. Without ExecutePageContext or ExecuteComponentContext, it's context.Background().
+		ContextFuncs funcs.ContextMapBuilderFunc
+		// CacheTemplates parses each layout, page, and component file once, reusing its parse trees in later executions
+		// rather than reading and parsing it again, until invalidated via Invalidate or InvalidateAll.
+		// Functions used by the files are then checked when executed, rather than parsed.
+		// Components of Config.RawComponents are not cached.
+		CacheTemplates bool
+		// Locales are the BCP 47 language tags of the languages pages are available in, eg "en" and "de",
+		// linked by the `hreflangs` template function. The first is the default, linked as x-default.
+		Locales []string
+		// LocaleURLPattern is the URL of a page in a locale, {locale} being replaced by the locale,
+		// and {path} by the path of the page, eg https://{locale}.example.com{path}. Defaults to /{locale}{path}.
+		LocaleURLPattern string
 	}
 
 	DirsConfig struct {
 		Base       string
 		Pages      string
 		Components string
+		// Icons holds the SVG files used by the `svg` template function.
+		Icons string
+		// Images holds the image files used by the `lqip` template function.
+		Images string
+	}
+
+	// caches holds the state shared by a Templater and its copies.
+	caches struct {
+		// svgs holds the contents of SVG files by path.
+		svgs sync.Map
+		// components holds the *template.Template of each component registered via RegisterComponent, by name.
+		components sync.Map
+		// overrides holds the source of each layout and page overridden via Override, by overrideKey.
+		overrides sync.Map
+		// lqips holds the placeholder data URIs of image files by path.
+		lqips sync.Map
+		// qrcodes holds the SVGs of the `qrcode` template function by qrcodeKey.
+		qrcodes sync.Map
+		// outputs holds the cachedOutput of components used via `componentCached`, by key, when Config.ComponentCacheTTL is set.
+		outputs sync.Map
+		// parsed holds the parse trees of template files by parsedFileKey, when Config.CacheTemplates is set.
+		parsed sync.Map
+		// dependencies caches the graph of DependencyGraph.
+		dependencies dependencyGraph
 	}
 
 	executionContext struct {
 		cfg      *Config
+		caches   *caches
+		render   *renderState
 		parent   *executionContext
 		template *template.Template
+		// isPage is set on the context executing a page, as opposed to a component or slot.
+		isPage bool
+		// depth is the number of ancestor contexts.
+		depth int
+		// node records the execution when rendering a tree via RenderTree, otherwise nil.
+		node *RenderNode
+		// headers holds the request headers provided by WithHeaders.
+		headers http.Header
+		// consent holds the consent categories granted via WithConsent.
+		consent []string
+		// nonce holds the CSP nonce provided by WithNonce.
+		nonce string
+		// rawQuery holds the query string provided to WithQuery.
+		rawQuery string
+		// experiments holds the experiment variants assigned via WithExperiments, by experiment name.
+		experiments map[string]string
+		// page is the name of the page being executed, if any.
+		page string
+		// ctx is the context of the execution, if provided via ExecutePageContext or ExecuteComponentContext.
+		ctx context.Context
+		// callProps are the props provided where the component executed next within this one was used,
+		// eg "Title" of {{ component "card" "Title" .Title }}, for Config.CollectState. Taken by writeComponent.
+		callProps map[string]any
 	}
 )
 
 func (tm *Templater) With(cfg Config) *Templater {
+	// the poller of any previous configuration uses the caches, so is stopped before they're replaced
+	tm.stopPolling()
+
 	tm.cfg = cfg
 	tm.cfg.setDefaultsToZeroFields()
+	tm.caches = new(caches)
+	tm.startPolling()
 	return tm
 }
 
@@ -130,11 +416,76 @@ func (tm *Templater) WithFuncs(m template.FuncMap) *Templater {
 	return &cpy
 }
 
+// WithQuery returns a copy of the Templater which includes the parameters of the raw query string
+// in the props of every execution. Repeated parameters are provided as a []string.
+// Props provided at execution take precedence over query parameters.
+func (tm *Templater) WithQuery(raw string) *Templater {
+	cpy := *tm
+	cpy.query = funcs.ParseQuery(raw)
+	cpy.rawQuery = raw
+	return &cpy
+}
+
+// newProps constructs the props of a top-level execution from the given key-value pairs.
+func (tm *Templater) newProps(kvs ...any) (map[string]any, error) {
+	props, err := funcs.NewKVSProps(kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range tm.query {
+		if _, ok := props[k]; !ok {
+			props[k] = v
+		}
+	}
+
+	return props, nil
+}
+
 func (tm *Templater) newContext() *executionContext {
 	cfg := tm.cfg
 	return &executionContext{
-		cfg: &cfg,
+		cfg:         &cfg,
+		caches:      tm.caches,
+		render:      newRenderState(),
+		headers:     tm.headers,
+		consent:     tm.consent,
+		nonce:       tm.nonce,
+		rawQuery:    tm.rawQuery,
+		experiments: tm.experiments,
+		ctx:         tm.ctx,
+	}
+}
+
+// finishOutput checks and encodes the output of a top-level execution, as configured.
+func (tm *Templater) finishOutput(b []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tm.cfg.PostProcessors) > 0 {
+		if b, err = postProcess(b, tm.cfg.PostProcessors); err != nil {
+			return nil, err
+		}
+	}
+
+	if tm.cfg.CheckDuplicateIDs {
+		if err := checkDuplicateIDs(b); err != nil {
+			return nil, err
+		}
+	}
+
+	if tm.cfg.AutoNonce && tm.nonce != "" {
+		b = addNonces(b, tm.nonce)
+	}
+
+	if tm.cfg.OutputEncoding != "" {
+		if b, err = encodeOutput(b, tm.cfg.OutputEncoding); err != nil {
+			return nil, err
+		}
 	}
+
+	return b, nil
 }
 
 func (c *Config) setDefaultsToZeroFields() {
@@ -144,12 +495,53 @@ func (c *Config) setDefaultsToZeroFields() {
 
 	c.Dirs.setDefaultsToZeroFields()
 
+	if c.FS == nil {
+		c.FS = osFS{}
+	}
+	if len(c.OverlayDirs) > 0 {
+		c.FS = newOverlayFS(c.FS, c.Dirs.Base, c.OverlayDirs)
+	}
+
 	if c.FileExt == "" {
 		c.FileExt = ".html.tmpl"
 	}
 	if c.FileExt[0] != '.' {
 		c.FileExt = "." + c.FileExt
 	}
+
+	if c.MaxDepth == 0 {
+		c.MaxDepth = 100
+	}
+
+	if c.ImagesURL == "" {
+		c.ImagesURL = "/" + c.Dirs.Images
+	}
+
+	if c.LocaleURLPattern == "" {
+		c.LocaleURLPattern = "/{locale}{path}"
+	}
+}
+
+// renderHooks calls render, between the BeforeRender and AfterRender hooks, if set.
+func (c *Config) renderHooks(kind, name string, props map[string]any, render func() ([]byte, error)) ([]byte, error) {
+	if c.BeforeRender != nil {
+		c.BeforeRender(kind, name, props)
+	}
+
+	b, err := render()
+
+	if c.AfterRender != nil {
+		b, err = c.AfterRender(kind, name, b, err)
+	}
+
+	return b, err
+}
+
+func (c *Config) trimComponentOutput(b []byte) []byte {
+	if !c.TrimComponentOutput || bytes.Contains(b, []byte("<pre")) || bytes.Contains(b, []byte("<textarea")) {
+		return b
+	}
+	return bytes.TrimSpace(b)
 }
 
 func (c *DirsConfig) setDefaultsToZeroFields() {
@@ -162,28 +554,36 @@ func (c *DirsConfig) setDefaultsToZeroFields() {
 	if c.Components == "" {
 		c.Components = "components"
 	}
+	if c.Icons == "" {
+		c.Icons = "icons"
+	}
+	if c.Images == "" {
+		c.Images = "images"
+	}
 }
 
 // ExecutePage is basically ExecuteComponent except returns html wrapped up in the layout page.
 func (tm *Templater) ExecutePage(name string, kvs ...any) ([]byte, error) {
-	props, err := funcs.NewKVSProps(kvs...)
+	props, err := tm.newProps(kvs...)
 	if err != nil {
 		return nil, err
 	}
 
-	return tm.newContext().executePage(name, props)
+	return tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		return ec.executePage(name, props)
+	}))
 }
 
 // ExecuteComponent allows for dynamic template lookup and execution
 // It expects an even number of kvs (allows for zero).
 // They are treated as key-value pairs and passed in a map[string]any to the template.
 func (tm *Templater) ExecuteComponent(name string, kvs ...any) ([]byte, error) {
-	props, err := funcs.NewKVSProps(kvs...)
+	props, err := tm.newProps(kvs...)
 	if err != nil {
 		return nil, err
 	}
 
-	return tm.newContext().executeComponent(name, props)
+	return tm.finishOutput(tm.newContext().executeComponent(name, props))
 }
 
 // Execute is a convenience function, executing the first template matching the given name,
@@ -191,119 +591,273 @@ func (tm *Templater) ExecuteComponent(name string, kvs ...any) ([]byte, error) {
 // If name conflicts exist between pages and components, then it's recommend to use ExecutePage
 // or ExecuteComponent instead.
 func (tm *Templater) Execute(name string, kvs ...any) ([]byte, error) {
-	props, err := funcs.NewKVSProps(kvs...)
+	props, err := tm.newProps(kvs...)
 	if err != nil {
 		return nil, err
 	}
 
-	return tm.newContext().execute(name, props)
+	return tm.finishOutput(tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		return ec.execute(name, props)
+	}))
 }
 
 func (ec *executionContext) executePage(name string, props map[string]any) ([]byte, error) {
+	return ec.cfg.renderHooks(RenderKindPage, name, props, func() ([]byte, error) {
+		return ec.renderPage(name, props)
+	})
+}
+
+func (ec *executionContext) renderPage(name string, props map[string]any) ([]byte, error) {
+	var layout *template.Template
+	if err := ec.render.timed(TimingParse, "Parse", func() (err error) {
+		layout, err = ec.parsePage(name, props)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := ec.render.timed(TimingExecute, "Execute", func() error {
+		return ec.render.executeLayout(buf, layout, props)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute html template: %w", err)
+	}
+
+	b := ec.render.bundleAssets(buf.Bytes())
+	if ec.cfg.CollectState {
+		return ec.render.emitState(b)
+	}
+
+	return b, nil
+}
+
+// parsePage parses the layout template, with the page of the given name defined as its "body" template.
+func (ec *executionContext) parsePage(name string, props map[string]any) (*template.Template, error) {
 	// find a matching file, and parse the path parameters
 
-	filename := name + ec.cfg.FileExt
 	pageDir := path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Pages)
 
-	match, err := findBestFilenameMatchInDir(name, ec.cfg.FileExt, pageDir)
-	if err != nil {
-		return nil, err
-	}
+	var pageFile string
+	body, overridden := ec.caches.override(RenderKindPage, name)
+	if overridden {
+		props["PathParams"] = map[string]any{}
+	} else {
+		match, filename, err := ec.findTemplateFile(name, pageDir, props)
+		if err != nil {
+			return nil, err
+		}
 
-	props["PathParams"], _, err = getPathParameters(match, filename)
-	if err != nil {
-		return nil, err
+		props["PathParams"], _, err = getPathParameters(match, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		pageFile = path.Join(pageDir, match)
 	}
 
 	// parse the layout template
 
-	layoutFilename := "layout" + ec.cfg.FileExt
+	ec.isPage = true
+	ec.page = name
+	ec.node = ec.node.add(RenderKindPage, name, props)
 
-	layout, err := template.New(layoutFilename).
-		Funcs(ec.buildFuncMap(name, props)).
-		ParseFiles(path.Join(ec.cfg.Dirs.Base, layoutFilename))
+	layout, err := ec.parseLayout(name, props)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse layout html file: %w", err)
+		return nil, err
 	}
 
 	// define "body" template
 
-	if b, err := os.ReadFile(path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Pages, match)); err != nil {
-		return nil, fmt.Errorf("failed to read page body html file: %w", err)
+	if overridden {
+		_, err = layout.New("body").Parse(body)
 	} else {
-		if _, err := layout.New("body").Parse(string(b)); err != nil {
-			return nil, fmt.Errorf("failed to parse body html template: %w", err)
-		}
+		_, err = ec.parseFile(layout, pageFile, "body")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse body html template: %w", err)
 	}
 
 	if ec.template, err = layout.Clone(); err != nil {
 		return nil, fmt.Errorf("failed to clone layout template for component execution: %w", err)
 	}
 
+	return layout, nil
+}
+
+func (ec *executionContext) executeComponent(name string, props map[string]any) ([]byte, error) {
+	return ec.cfg.renderHooks(RenderKindComponent, name, props, func() ([]byte, error) {
+		return ec.renderComponent(name, props)
+	})
+}
+
+// parseLayout parses the layout template, or the source overriding it.
+func (ec *executionContext) parseLayout(name string, props map[string]any) (*template.Template, error) {
+	layoutFilename := "layout" + ec.cfg.FileExt
+
+	layout := template.New(layoutFilename).
+		Funcs(ec.buildFuncMap(name, props))
+
+	var err error
+	if source, ok := ec.caches.override(OverrideKindLayout, ""); ok {
+		layout, err = layout.Parse(source)
+	} else {
+		layout, err = ec.parseFile(layout, path.Join(ec.cfg.Dirs.Base, layoutFilename), layoutFilename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse layout html file: %w", err)
+	}
+
+	return layout, nil
+}
+
+func (ec *executionContext) renderComponent(name string, props map[string]any) ([]byte, error) {
 	buf := new(bytes.Buffer)
-	if err := layout.Execute(buf, props); err != nil {
-		return nil, fmt.Errorf("failed to execute html template: %w", err)
+	file, err := ec.writeComponent(buf, name, props)
+	if err != nil {
+		return nil, err
 	}
 
-	return buf.Bytes(), nil
+	return ec.render.markSource(name, file, buf.Bytes()), nil
 }
 
-func (ec *executionContext) executeComponent(name string, props map[string]any) ([]byte, error) {
-	filename := name + ec.cfg.FileExt
-	componentDir := path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Components)
+// writeComponent executes the component of the given name, writing its output to w,
+// and returns the path of its component file, or empty for registered components.
+func (ec *executionContext) writeComponent(w io.Writer, name string, props map[string]any) (string, error) {
+	callProps := ec.callProps
+	ec.callProps = nil
+	if callProps == nil {
+		// executed other than via a template function, eg ExecuteComponent, so every prop was provided by the caller
+		callProps = props
+	}
+
+	// find the component, and parse the path parameters
 
-	match, err := findBestFilenameMatchInDir(name, ec.cfg.FileExt, componentDir)
+	componentDir := path.Join(ec.cfg.Dirs.Base, ec.cfg.Dirs.Components)
+	registered, match, filename, err := ec.resolveComponent(name, componentDir, props)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+
+	if registered != nil {
+		props["PathParams"] = map[string]any{}
+	} else if props["PathParams"], _, err = getPathParameters(match, filename); err != nil {
+		return "", err
 	}
 
-	pathParams, _, err := getPathParameters(match, filename)
+	cc, err := ec.newChild(name)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	if err := ec.countInstance(name); err != nil {
+		return "", err
+	}
+	if ec.cfg.CollectState {
+		ec.render.collectState(name, callProps)
 	}
+	cc.node = ec.node.add(RenderKindComponent, name, props)
 
-	props["PathParams"] = pathParams
+	// parse the component
 
-	cc := &executionContext{
-		cfg:    ec.cfg,
-		parent: ec,
+	if registered == nil && slices.Contains(ec.cfg.RawComponents, name) {
+		b, err := cc.executeRaw(name, path.Join(componentDir, match), props)
+		if err != nil {
+			return "", err
+		}
+		if _, err := w.Write(b); err != nil {
+			return "", fmt.Errorf("failed to write component %s: %w", name, err)
+		}
+		if err := ec.collectComponentAssets(componentDir, match); err != nil {
+			return "", err
+		}
+		return componentFile(componentDir, match), nil
 	}
 
-	t := template.New(name).
-		Funcs(cc.buildFuncMap(name, props))
-	if t, err = t.ParseFiles(path.Join(componentDir, match)); err != nil {
-		return nil, fmt.Errorf("failed to parse component %s: %w", name, err)
+	var t *template.Template
+	var entry string
+	if registered != nil {
+		if t, err = registered.Clone(); err != nil {
+			return "", fmt.Errorf("failed to clone registered component %s: %w", name, err)
+		}
+		t.Funcs(cc.buildFuncMap(name, props))
+		entry = registered.Name()
+	} else {
+		t = template.New(name).
+			Funcs(cc.buildFuncMap(name, props))
+		if t, err = ec.parseFile(t, path.Join(componentDir, match), path.Base(match)); err != nil {
+			return "", fmt.Errorf("failed to parse component %s: %w", name, err)
+		}
+		entry = path.Base(match)
 	}
 
 	if known := ec.template; known != nil {
 		cl, err := known.Clone()
 		if err != nil {
-			return nil, fmt.Errorf("failed to clone template: %w", err)
+			return "", fmt.Errorf("failed to clone template: %w", err)
 		}
 		for _, st := range cl.Templates() {
 			if _, err := t.AddParseTree(st.Name(), st.Tree); err != nil {
-				return nil, fmt.Errorf("failed to add tree of known template to component template: %w", err)
+				return "", fmt.Errorf("failed to add tree of known template to component template: %w", err)
 			}
 		}
 	}
 
 	if cc.template, err = t.Clone(); err != nil {
-		return nil, fmt.Errorf("failed to create template clone: %w", err)
+		return "", fmt.Errorf("failed to create template clone: %w", err)
 	}
 
-	buf := new(bytes.Buffer)
-	if err := t.ExecuteTemplate(buf, path.Base(match), props); err != nil {
-		return nil, fmt.Errorf("failed to execute component %s: %w", name, err)
+	if err := t.ExecuteTemplate(w, entry, props); err != nil {
+		return "", fmt.Errorf("failed to execute component %s: %w", name, err)
 	}
 
-	return buf.Bytes(), nil
+	if err := ec.collectComponentAssets(componentDir, match); err != nil {
+		return "", err
+	}
+
+	return componentFile(componentDir, match), nil
+}
+
+// componentFile returns the path of the component file matched in the components directory,
+// or empty for registered components, having no file.
+func componentFile(componentDir, match string) string {
+	if match == "" {
+		return ""
+	}
+	return path.Join(componentDir, match)
+}
+
+// componentErrorsKey is the key the errors recovered from by componentSafe are stored under,
+// eg to list them in a layout's footer with {{ range retrieve "componentErrors" }}.
+const componentErrorsKey = "componentErrors"
+
+// executeComponentSafe executes the component of the given name, or if that fails,
+// stores the error and executes the fallback component with the same props and the error as the "error" prop.
+func (ec *executionContext) executeComponentSafe(name, fallback string, props map[string]any) ([]byte, error) {
+	callProps := ec.callProps
+
+	b, err := ec.executeComponent(name, maps.Clone(props))
+	if err == nil {
+		return b, nil
+	}
+
+	ec.render.storeValue(componentErrorsKey, err)
+
+	props["error"] = err
+	ec.callProps = callProps
+	return ec.executeComponent(fallback, props)
 }
 
 func (ec *executionContext) executeSlot(name string, props map[string]any) ([]byte, error) {
-	cc := &executionContext{
-		cfg:    ec.cfg,
-		parent: ec,
+	return ec.cfg.renderHooks(RenderKindSlot, name, props, func() ([]byte, error) {
+		return ec.renderSlot(name, props)
+	})
+}
+
+func (ec *executionContext) renderSlot(name string, props map[string]any) ([]byte, error) {
+	cc, err := ec.newChild(name)
+	if err != nil {
+		return nil, err
 	}
+	cc.node = ec.node.add(RenderKindSlot, name, props)
 
 	t := template.New(name).
 		Funcs(cc.buildFuncMap(name, props))
@@ -343,6 +897,37 @@ func (ec *executionContext) executeSlot(name string, props map[string]any) ([]by
 	return buf.Bytes(), nil
 }
 
+// newChild creates the context of a component or slot executed within this one.
+func (ec *executionContext) newChild(name string) (*executionContext, error) {
+	if ec.depth >= ec.cfg.MaxDepth {
+		return nil, &ErrMaxDepthExceeded{
+			Name:     name,
+			MaxDepth: ec.cfg.MaxDepth,
+		}
+	}
+	if err := ec.countRender(); err != nil {
+		return nil, err
+	}
+	if err := ec.contextErr(); err != nil {
+		return nil, err
+	}
+
+	return &executionContext{
+		cfg:         ec.cfg,
+		caches:      ec.caches,
+		render:      ec.render,
+		headers:     ec.headers,
+		consent:     ec.consent,
+		nonce:       ec.nonce,
+		rawQuery:    ec.rawQuery,
+		experiments: ec.experiments,
+		page:        ec.page,
+		ctx:         ec.ctx,
+		parent:      ec,
+		depth:       ec.depth + 1,
+	}, nil
+}
+
 func (ec *executionContext) execute(name string, props map[string]any) ([]byte, error) {
 	b, perr := ec.executePage(name, props)
 	if perr == nil {
@@ -362,15 +947,50 @@ func (ec *executionContext) execute(name string, props map[string]any) ([]byte,
 	return nil, errors.Join(perr, cerr)
 }
 
+// findTemplateFile finds the template file in dir best matching name,
+// returning its path relative to dir, and the filename it was matched against.
+// If Config.VariantSelector selects a variant, eg "B", the file for that variant, eg name.B.html.tmpl,
+// is preferred, falling back to the file of name itself when no such variant exists.
+func (ec *executionContext) findTemplateFile(name, dir string, props map[string]any) (match, filename string, err error) {
+	if ec.cfg.VariantSelector != nil {
+		if variant := ec.cfg.VariantSelector(name, props); variant != "" {
+			variantName := name + "." + variant
+
+			match, err := findBestFilenameMatchInDir(ec.cfg.FS, variantName, ec.cfg.FileExt, dir)
+			if err == nil && strings.HasSuffix(match, "."+variant+ec.cfg.FileExt) {
+				return match, variantName + ec.cfg.FileExt, nil
+			}
+
+			var te *ErrNotTemplateFileFound
+			if err != nil && !errors.As(err, &te) {
+				return "", "", err
+			}
+			// otherwise the variant doesn't exist, or only matched a wildcard
+		}
+	}
+
+	match, err = findBestFilenameMatchInDir(ec.cfg.FS, name, ec.cfg.FileExt, dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	return match, name + ec.cfg.FileExt, nil
+}
+
 // findBestFilenameMatchInDir finds the most exact match for a filename, allowing for path segments wildcards for the form {\w+}.
 // supports index.html files.
-func findBestFilenameMatchInDir(filenameBase, ext, dir string) (string, error) {
+func findBestFilenameMatchInDir(fsys fs.FS, filenameBase, ext, dir string) (string, error) {
 	filename := filenameBase + ext
 	filenameBaseSegments := getPathSegments(filenameBase)
 
 	var matchesFound [][]string
 
-	err := fs.WalkDir(os.DirFS(dir), ".", func(p string, d fs.DirEntry, err error) error {
+	dirFS, err := subFS(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+
+	err = fs.WalkDir(dirFS, ".", func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -548,13 +1168,13 @@ func (ec *executionContext) buildFuncMap(name string, props map[string]any) temp
 	m := template.FuncMap(map[string]any{
 		// template execution
 		"component": func(name string, kvs ...any) (template.HTML, error) {
-			cpy, err := addProps(props, kvs...)
+			cpy, err := ec.addCallProps(props, kvs...)
 			if err != nil {
 				return "", err
 			}
 
-			b, err := ec.executeComponent(name, cpy)
-			return template.HTML(b), err
+			b, err := ec.executeComponentOrMissing(name, cpy)
+			return template.HTML(ec.cfg.trimComponentOutput(b)), err
 		},
 		"slot": func(name string, kvs ...any) (template.HTML, error) {
 			cpy, err := addProps(props, kvs...)
@@ -563,14 +1183,133 @@ func (ec *executionContext) buildFuncMap(name string, props map[string]any) temp
 			}
 
 			b, err := ec.executeSlot(name, cpy)
-			return template.HTML(b), err
+			return template.HTML(ec.cfg.trimComponentOutput(b)), err
+		},
+		"hasSlot": func(name string) bool {
+			return hasSlot(props, name)
+		},
+		"slotContent": func(name string, kvs ...any) (template.HTML, error) {
+			cpy, err := addProps(props, kvs...)
+			if err != nil {
+				return "", err
+			}
+
+			b, err := ec.slotContent(name, cpy)
+			return template.HTML(ec.cfg.trimComponentOutput(b)), err
+		},
+		"componentSafe": func(name, fallback string, kvs ...any) (template.HTML, error) {
+			cpy, err := ec.addCallProps(props, kvs...)
+			if err != nil {
+				return "", err
+			}
+
+			b, err := ec.executeComponentSafe(name, fallback, cpy)
+			return template.HTML(ec.cfg.trimComponentOutput(b)), err
+		},
+		"componentCached": func(name string, kvs ...any) (template.HTML, error) {
+			cpy, err := ec.addCallProps(props, kvs...)
+			if err != nil {
+				return "", err
+			}
+
+			b, err := ec.executeComponentCached(name, cpy)
+			return template.HTML(ec.cfg.trimComponentOutput(b)), err
+		},
+		"lazyComponent": ec.lazyComponent,
+		"ifVisible": func(name string, kvs ...any) (template.HTML, error) {
+			return ec.ifVisible(name, props, kvs...)
+		},
+		"skeleton": func(name string, kvs ...any) (template.HTML, error) {
+			cpy, err := ec.addCallProps(props, kvs...)
+			if err != nil {
+				return "", err
+			}
+
+			b, err := ec.executeSkeleton(name, cpy)
+			return template.HTML(ec.cfg.trimComponentOutput(b)), err
+		},
+
+		// validation
+		"safeURLStrict": ec.cfg.URLPolicy.SafeURLStrict,
+		"sanitizeHTML":  ec.sanitizeHTML,
+
+		// render context
+		"isEmbedded": ec.isEmbedded,
+		"buildInfo":  ec.buildInfo,
+		"store":      ec.render.storeValue,
+		"retrieve":   ec.render.retrieve,
+		"uniqueID":   ec.render.uniqueID,
+		"refID":      ec.render.refID,
+		"frame":      ec.render.frame,
+		"headItem":   ec.render.headItem,
+		"headItems":  ec.render.headItems,
+		"rolloutEnabled": func(flag, key string) bool {
+			return funcs.InRollout(ec.cfg.Rollouts[flag], flag, key)
+		},
+
+		// request
+		"header":      ec.header,
+		"withConsent": ec.withConsent,
+		"cspNonce":    ec.cspNonce,
+		"experiment":  ec.experiment,
+
+		// markup
+		"image":      ec.cfg.Images.Image,
+		"sortHeader": funcs.SortQuery(ec.rawQuery).SortHeader,
+		"lqip":       ec.lqip,
+		"qrcode":     ec.qrcode,
+
+		"breadcrumbsLD": ec.breadcrumbsLD,
+		"ogImage":       ec.ogImage,
+		"hreflangs":     ec.hreflangs,
+
+		// colors
+		"colorFromString": funcs.Palette(ec.cfg.ColorPalette).ColorFromString,
+
+		// formatting
+		"pluralize":   funcs.Pluralizer{Plurals: ec.cfg.Plurals}.Pluralize,
+		"readingTime": funcs.ReadingSpeed(ec.cfg.WordsPerMinute).ReadingTime,
+
+		// sorting
+		"sortStrings": funcs.Collation(ec.cfg.Locale).SortStrings,
+		"sortedKeys":  funcs.Collation(ec.cfg.Locale).SortedKeys,
+
+		"svg": ec.svg,
+
+		// data
+		"load": func(loader string) (any, error) {
+			load, ok := ec.cfg.DataLoaders[loader]
+			if !ok {
+				return nil, fmt.Errorf("data loader %s not configured", loader)
+			}
+
+			v, err := load(props)
+			if err != nil {
+				return nil, fmt.Errorf("data loader %s failed: %w", loader, err)
+			}
+			return v, nil
 		},
 	})
 
+	m = ec.trackImpureCalls(m)
+
 	maps.Copy(m, funcs.DefaultMap(name, props))
 	maps.Copy(m, ec.cfg.Funcs(name, props))
+	if ec.cfg.ContextFuncs != nil {
+		maps.Copy(m, ec.cfg.ContextFuncs(ec.context(), name, props))
+	}
 
-	return m
+	return ec.cancelFuncCalls(ec.limitFuncCalls(m))
+}
+
+// isEmbedded reports whether the execution is nested within a page execution.
+func (ec *executionContext) isEmbedded() bool {
+	for c := ec; c != nil; c = c.parent {
+		if c.isPage {
+			return true
+		}
+	}
+	return false
 }
 
 func addProps(props map[string]any, kvs ...any) (map[string]any, error) {
@@ -578,6 +1317,9 @@ func addProps(props map[string]any, kvs ...any) (map[string]any, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkSlotKeys(kvs...); err != nil {
+		return nil, err
+	}
 
 	cpy := make(map[string]any, len(props))
 	maps.Copy(cpy, props)
@@ -606,6 +1348,9 @@ func getPathSegments(p string) []string {
 	return strings.Split(p, "/")
 }
 
+// getPathParameters matches targetPath against the pattern, returning the values of its wildcard segments by name.
+// Wildcards declaring a type, eg {id.int}, have their values parsed as that type,
+// resulting in an *ErrInvalidWildcardValue if parsing fails. Untyped wildcards, eg {id}, remain strings.
 func getPathParameters(pattern, targetPath string) (params map[string]any, match bool, err error) {
 	ext := getExtendedExtension(pattern)
 	targetPathExt := getExtendedExtension(targetPath)
@@ -1,8 +1,11 @@
 package templater
 
 import (
+	"bytes"
+	"errors"
 	"html/template"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -234,6 +237,86 @@ func TestTemplater_ExecuteComponent(t *testing.T) {
   <div>
     BBB
   </div>
+</div>`,
+			},
+		},
+		{
+			Name: "Given a component " +
+				"And the component is disabled " +
+				"Then a disabled error is returned",
+			Args: Args{
+				Config: Config{
+					Dirs: DirsConfig{
+						Base:       "test_dir/test_templates",
+						Pages:      "test_pages",
+						Components: "test_components",
+					},
+					DisabledComponents: []string{"component_1"},
+				},
+				Name: "component_1",
+				KVs: []any{
+					"X", "abc",
+					"Y", 123,
+					"Z", true,
+				},
+			},
+			Expected: Expected{
+				Error: &ErrComponentDisabled{
+					Name: "component_1",
+				},
+			},
+		},
+		{
+			Name: "Given a component " +
+				"And a different component is disabled " +
+				"Then the component is rendered",
+			Args: Args{
+				Config: Config{
+					Dirs: DirsConfig{
+						Base:       "test_dir/test_templates",
+						Pages:      "test_pages",
+						Components: "test_components",
+					},
+					DisabledComponents: []string{"component_2"},
+				},
+				Name: "component_1",
+				KVs: []any{
+					"X", "abc",
+					"Y", 123,
+					"Z", true,
+				},
+			},
+			Expected: Expected{
+				Bytes: `<div>
+  <div>
+    abc
+  </div>
+  <div>
+    123
+  </div>
+  <div>
+    true
+  </div>
+</div>`,
+			},
+		},
+		{
+			Name: "Given a component " +
+				"And the component is not executed within a page " +
+				"Then the component is not embedded",
+			Args: Args{
+				Config: Config{
+					Dirs: DirsConfig{
+						Base:       "test_dir/test_templates",
+						Pages:      "test_pages",
+						Components: "test_components",
+					},
+				},
+				Name: "embedded",
+			},
+			Expected: Expected{
+				Bytes: `<div>
+  embedded: false
 </div>`,
 			},
 		},
@@ -495,6 +578,44 @@ func TestTemplater_ExecutePage(t *testing.T) {
       FOOTER
     </footer>
   </body>
+</html>`,
+			},
+		},
+		{
+			Name: "Given a page " +
+				"With a nested component " +
+				"Then the component is embedded",
+			Args: Args{
+				Config: Config{
+					Dirs: DirsConfig{
+						Base:       "test_dir/test_templates",
+						Pages:      "test_pages",
+						Components: "test_components",
+					},
+				},
+				Name: "embedded_page",
+			},
+			Expected: Expected{
+				Bytes: `<!DOCTYPE html>
+<html>
+  <head>
+    <title>
+      ABC
+    </title>
+  </head>
+  <body>
+    <header>
+      HEAD
+    </header>
+    <div>
+      <div>
+        embedded: true
+      </div>
+    </div>
+    <footer>
+      FOOTER
+    </footer>
+  </body>
 </html>`,
 			},
 		},
@@ -515,3 +636,373 @@ func TestTemplater_ExecutePage(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplater_ExecuteComponent_MaxDepth(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		MaxDepth: 10,
+	})
+
+	_, err := tm.ExecuteComponent("recursive", "Path", "")
+
+	var de *ErrMaxDepthExceeded
+	require.ErrorAs(t, err, &de, "unexpected error returned: %+v", err)
+	assert.Equal(t, &ErrMaxDepthExceeded{Name: "recursive", MaxDepth: 10}, de, "unexpected error returned")
+}
+
+func TestTemplater_WithQuery(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.WithQuery("a=1&a=2&b=x").ExecutePage("query_page", "b", "y")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Contains(t, string(b), "<span>1</span><span>2</span>", "expected repeated query params to be a slice")
+	assert.Contains(t, string(b), "<p>y</p>", "expected provided props to take precedence over query params")
+}
+
+func TestTemplater_TrimComponentOutput(t *testing.T) {
+	const inline = `[{{ component "spaced" "Text" "a" }}][{{ component "preformatted" "Text" "b" }}]`
+
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	}
+
+	b, err := new(Templater).With(cfg).ExecuteInline(inline)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, "[\n\t<span>a</span>\n\n][\n<pre>  b  </pre>\n\n]", string(b), "unexpected bytes returned")
+
+	cfg.TrimComponentOutput = true
+
+	b, err = new(Templater).With(cfg).ExecuteInline(inline)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, "[<span>a</span>][\n<pre>  b  </pre>\n\n]", string(b), "unexpected bytes returned")
+}
+
+func TestTemplater_DataLoaders(t *testing.T) {
+	type User struct {
+		Name string
+	}
+
+	cfg := Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		DataLoaders: map[string]func(props map[string]any) (any, error){
+			"user": func(props map[string]any) (any, error) {
+				if props["ID"] != 7 {
+					return nil, errors.New("user not found")
+				}
+				return User{Name: "Ann"}, nil
+			},
+		},
+	}
+
+	b, err := new(Templater).With(cfg).ExecuteComponent("loaded", "ID", 7)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `<div>
+  Ann
+</div>`, gohtml.Format(string(b)), "unexpected bytes returned")
+
+	_, err = new(Templater).With(cfg).ExecuteComponent("loaded", "ID", 8)
+	assert.ErrorContains(t, err, "user not found", "expected the loader error to be returned")
+}
+
+func TestTemplater_VariantSelector(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		VariantSelector: func(name string, props map[string]any) string {
+			variant, _ := props["Variant"].(string)
+			return variant
+		},
+	})
+
+	b, err := tm.ExecutePage("variant", "Variant", "B")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Contains(t, string(b), "variant B", "expected the selected variant to be executed")
+
+	b, err = tm.ExecutePage("variant", "Variant", "C")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Contains(t, string(b), "variant A", "expected a missing variant to fall back to the base template")
+
+	b, err = tm.ExecutePage("variant")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Contains(t, string(b), "variant A", "expected no variant to execute the base template")
+}
+
+func TestTemplater_Rollouts(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		Rollouts: map[string]float64{
+			"everyone": 100,
+			"no-one":   0,
+		},
+	})
+
+	b, err := tm.ExecuteInline(`{{ rolloutEnabled "everyone" .ID }} {{ rolloutEnabled "no-one" .ID }} {{ rolloutEnabled "unknown" .ID }}`, "ID", "user-1")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, "true false false", string(b), "unexpected bytes returned")
+}
+
+func TestTemplater_BuildInfo(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		BuildInfo: &BuildInfo{
+			Version: "v1.2.3",
+			Commit:  "abc123",
+			BuiltAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	})
+
+	b, err := tm.ExecuteInline(`{{ buildInfo.Version }} {{ buildInfo.Commit }} {{ buildInfo.BuiltAt.Format "2006-01-02" }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, "v1.2.3 abc123 2024-01-02", string(b), "unexpected bytes returned")
+}
+
+func TestGetPathParameters(t *testing.T) {
+	type (
+		Args struct {
+			Pattern    string
+			TargetPath string
+		}
+		Expected struct {
+			Params map[string]any
+			Match  bool
+			Error  error
+		}
+		Test struct {
+			Name     string
+			Args     Args
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given an untyped wildcard " +
+				"Then the value remains a string",
+			Args: Args{
+				Pattern:    "pets/{id}.html.tmpl",
+				TargetPath: "pets/123.html.tmpl",
+			},
+			Expected: Expected{
+				Params: map[string]any{"id": "123"},
+				Match:  true,
+			},
+		},
+		{
+			Name: "Given an int wildcard " +
+				"With an int value " +
+				"Then the value is parsed as an int",
+			Args: Args{
+				Pattern:    "pets/{id.int}.html.tmpl",
+				TargetPath: "pets/123.html.tmpl",
+			},
+			Expected: Expected{
+				Params: map[string]any{"id": 123},
+				Match:  true,
+			},
+		},
+		{
+			Name: "Given an int wildcard " +
+				"With a non-int value " +
+				"Then an invalid wildcard value error is returned",
+			Args: Args{
+				Pattern:    "pets/{id.int}.html.tmpl",
+				TargetPath: "pets/rex.html.tmpl",
+			},
+			Expected: Expected{
+				Error: &ErrInvalidWildcardValue{
+					Value: "rex",
+					Type:  "int",
+				},
+			},
+		},
+		{
+			Name: "Given a bool wildcard " +
+				"With a bool value " +
+				"Then the value is parsed as a bool",
+			Args: Args{
+				Pattern:    "{flag.bool}.html.tmpl",
+				TargetPath: "true.html.tmpl",
+			},
+			Expected: Expected{
+				Params: map[string]any{"flag": true},
+				Match:  true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			params, match, err := getPathParameters(test.Args.Pattern, test.Args.TargetPath)
+
+			if test.Expected.Error == nil {
+				require.NoError(t, err, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Params, params, "unexpected params returned")
+				assert.Equal(t, test.Expected.Match, match, "unexpected match returned")
+			} else {
+				var we *ErrInvalidWildcardValue
+				require.ErrorAs(t, err, &we, "unexpected error returned: %+v", err)
+				assert.Equal(t, test.Expected.Error.(*ErrInvalidWildcardValue).Value, we.Value, "unexpected wildcard value")
+				assert.Equal(t, test.Expected.Error.(*ErrInvalidWildcardValue).Type, we.Type, "unexpected wildcard type")
+			}
+		})
+	}
+}
+
+func TestTemplater_ExecuteComponent_InvalidPathParameter(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	_, err := tm.ExecuteComponent("top_dir/some-phrase/mid_dir/not-a-number/bottom_dir/last-part")
+
+	var we *ErrInvalidWildcardValue
+	require.ErrorAs(t, err, &we, "unexpected error returned: %+v", err)
+	assert.Equal(t, "not-a-number", we.Value, "unexpected wildcard value")
+	assert.Equal(t, "int64", we.Type, "unexpected wildcard type")
+}
+
+func TestTemplater_ComponentSafe(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	b, err := tm.ExecuteInline(`{{ componentSafe "broken" "fallback" "Title" "Chart" }}{{ len (retrieve "componentErrors") }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Regexp(t, `^<div class="error">Chart failed: .*index of untyped nil</div>\n1$`, string(b), "unexpected bytes returned")
+
+	b, err = tm.ExecuteInline(`{{ componentSafe "spaced" "fallback" }}{{ len (retrieve "componentErrors") }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.NotContains(t, string(b), "failed", "expected the fallback not to be used")
+	assert.Regexp(t, `0$`, string(b), "expected no error to be stored")
+
+	_, err = tm.ExecuteInline(`{{ componentSafe "broken" "no_such/component" }}`)
+	assert.Error(t, err, "expected the error of the fallback to be returned")
+}
+
+func TestTemplater_RenderHooks(t *testing.T) {
+	var before []string
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		BeforeRender: func(kind, name string, props map[string]any) {
+			before = append(before, kind+" "+name)
+		},
+		AfterRender: func(kind, name string, b []byte, err error) ([]byte, error) {
+			if kind == RenderKindComponent && name == "inner_component" {
+				b = bytes.ToUpper(b)
+			}
+			return b, err
+		},
+	})
+
+	b, err := tm.ExecuteComponent("outer_component", "A", "aaa")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	assert.Equal(t, []string{"component outer_component", "component inner_component", "slot header"}, before, "unexpected renders reported")
+	assert.Contains(t, string(b), "AAA", "expected the inner component's output to be transformed")
+	assert.Contains(t, string(b), "BBB", "expected the inner component's slot output to be transformed")
+	assert.Contains(t, string(b), "<div>\n", "expected the outer component's output not to be transformed")
+}
+
+func TestTemplater_Locale(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		Locale: "de",
+	})
+
+	b, err := tm.ExecuteInline(`{{ range sortStrings .Words }}{{ . }} {{ end }}`, "Words", []string{"Zucker", "Öl", "Äpfel"})
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `Äpfel Öl Zucker `, string(b), "unexpected bytes returned")
+}
+
+func TestTemplater_Plurals(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		Plurals: map[string]string{"person": "people"},
+	})
+
+	b, err := tm.ExecuteInline(`{{ pluralize 2 "person" }} {{ pluralize 2 "box" }} {{ pluralize 1 "person" }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `people boxes person`, string(b), "unexpected bytes returned")
+}
+
+func TestTemplater_ColorPalette(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		ColorPalette: []string{"#123456"},
+	})
+
+	b, err := tm.ExecuteInline(`{{ colorFromString "go" }} {{ colorFromString "rust" }}`)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `#123456 #123456`, string(b), "unexpected bytes returned")
+}
+
+func TestTemplater_WordsPerMinute(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+		WordsPerMinute: 2,
+	})
+
+	b, err := tm.ExecuteInline(`{{ readingTime .Content }}`, "Content", template.HTML("<p>one two <b>three</b></p>"))
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, `2`, string(b), "unexpected bytes returned")
+}
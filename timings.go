@@ -0,0 +1,89 @@
+package templater
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The names of the render phases timed by ExecutePageWithTimings.
+const (
+	TimingParse   = "parse"
+	TimingExecute = "execute"
+	TimingPost    = "post"
+)
+
+// ServerTiming is the duration of a render phase, formatted as a metric of a Server-Timing header by String.
+type ServerTiming struct {
+	Name        string
+	Description string
+	Duration    time.Duration
+}
+
+// String formats the timing as a Server-Timing metric, eg parse;desc="Parse";dur=1.250
+func (st ServerTiming) String() string {
+	var b strings.Builder
+
+	b.WriteString(st.Name)
+	if st.Description != "" {
+		b.WriteString(`;desc=`)
+		b.WriteString(strconv.Quote(st.Description))
+	}
+	b.WriteString(";dur=")
+	b.WriteString(strconv.FormatFloat(float64(st.Duration)/float64(time.Millisecond), 'f', 3, 64))
+
+	return b.String()
+}
+
+// FormatServerTiming formats the timings as the value of a Server-Timing header.
+func FormatServerTiming(timings []ServerTiming) string {
+	metrics := make([]string, len(timings))
+	for i, st := range timings {
+		metrics[i] = st.String()
+	}
+	return strings.Join(metrics, ", ")
+}
+
+// ExecutePageWithTimings is ExecutePage, additionally returning the durations of its render phases:
+// parsing the layout and page, executing them, components included, and post-processing the output,
+// eg Config.PostProcessors and Config.OutputEncoding.
+// They're intended for the Server-Timing header, via FormatServerTiming, for debugging performance in the browser.
+// With Config.TwoPass set, the phases of the second pass are timed.
+func (tm *Templater) ExecutePageWithTimings(name string, kvs ...any) ([]byte, []ServerTiming, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var timings []ServerTiming
+	b, err := tm.executePasses(props, func(ec *executionContext, props map[string]any) ([]byte, error) {
+		b, err := ec.executePage(name, props)
+		timings = ec.render.timings
+		return b, err
+	})
+
+	start := time.Now()
+	b, err = tm.finishOutput(b, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	timings = append(timings, ServerTiming{
+		Name:        TimingPost,
+		Description: "Post-process",
+		Duration:    time.Since(start),
+	})
+
+	return b, timings, nil
+}
+
+// timed calls fn, recording its duration under the name and description.
+func (rs *renderState) timed(name, description string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	rs.timings = append(rs.timings, ServerTiming{
+		Name:        name,
+		Description: description,
+		Duration:    time.Since(start),
+	})
+	return err
+}
@@ -0,0 +1,43 @@
+package templater
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecutePageWithTimings(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	expected, err := tm.ExecutePage("badges_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	b, timings, err := tm.ExecutePageWithTimings("badges_page")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, string(expected), string(b), "unexpected output")
+
+	names := make([]string, len(timings))
+	for i, st := range timings {
+		names[i] = st.Name
+	}
+	require.Equal(t, []string{TimingParse, TimingExecute, TimingPost}, names, "unexpected timings returned")
+	assert.Positive(t, timings[0].Duration, "expected the parse phase to be timed")
+	assert.Positive(t, timings[1].Duration, "expected the execute phase to be timed")
+}
+
+func TestFormatServerTiming(t *testing.T) {
+	header := FormatServerTiming([]ServerTiming{
+		{Name: TimingParse, Description: "Parse", Duration: 1250 * time.Microsecond},
+		{Name: TimingExecute, Duration: 3 * time.Millisecond},
+	})
+
+	assert.Equal(t, `parse;desc="Parse";dur=1.250, execute;dur=3.000`, header, "unexpected header value")
+}
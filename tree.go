@@ -0,0 +1,57 @@
+package templater
+
+import "maps"
+
+// The kinds of RenderNode.
+const (
+	RenderKindPage      = "page"
+	RenderKindComponent = "component"
+	RenderKindSlot      = "slot"
+)
+
+// RenderNode describes a page, component, or slot executed during a render,
+// along with the props it was executed with, and the components and slots executed within it, in order.
+type RenderNode struct {
+	Kind     string
+	Name     string
+	Props    map[string]any
+	Children []*RenderNode
+}
+
+// RenderTree executes the template of the given name, as Execute would,
+// returning the tree of pages, components, and slots executed, rather than the output.
+// It's intended for snapshot testing the composition of templates independent of their markup.
+func (tm *Templater) RenderTree(name string, kvs ...any) (*RenderNode, error) {
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return nil, err
+	}
+
+	root := new(RenderNode)
+
+	ec := tm.newContext()
+	ec.node = root
+
+	if _, err := ec.execute(name, props); err != nil {
+		return nil, err
+	}
+
+	return root.Children[0], nil
+}
+
+// add records a child execution of the node, returning the child node.
+// It's a no-op on a nil node, ie when not rendering a tree.
+func (n *RenderNode) add(kind, name string, props map[string]any) *RenderNode {
+	if n == nil {
+		return nil
+	}
+
+	child := &RenderNode{
+		Kind:  kind,
+		Name:  name,
+		Props: maps.Clone(props),
+	}
+	n.Children = append(n.Children, child)
+
+	return child
+}
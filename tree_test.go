@@ -0,0 +1,66 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_RenderTree(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		},
+	})
+
+	tree, err := tm.RenderTree("top_dir/asdfasdfasdf/the_page", "A", "AAA")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	type node struct {
+		Kind     string
+		Name     string
+		Children []node
+	}
+	var simplify func(n *RenderNode) node
+	simplify = func(n *RenderNode) node {
+		s := node{Kind: n.Kind, Name: n.Name}
+		for _, c := range n.Children {
+			s.Children = append(s.Children, simplify(c))
+		}
+		return s
+	}
+
+	assert.Equal(t, node{
+		Kind: RenderKindPage,
+		Name: "top_dir/asdfasdfasdf/the_page",
+		Children: []node{
+			{
+				Kind: RenderKindComponent,
+				Name: "top_dir/some-phrase/mid_dir/321/bottom_dir/last-part",
+				Children: []node{
+					{
+						Kind: RenderKindComponent,
+						Name: "component_2",
+						Children: []node{
+							{Kind: RenderKindComponent, Name: "component_1"},
+						},
+					},
+				},
+			},
+			{
+				Kind: RenderKindComponent,
+				Name: "component_2",
+				Children: []node{
+					{Kind: RenderKindComponent, Name: "component_1"},
+				},
+			},
+		},
+	}, simplify(tree), "unexpected tree returned")
+
+	assert.Equal(t, "AAA", tree.Props["A"], "unexpected page props")
+	assert.Equal(t, "QQQQ", tree.Children[0].Props["Q"], "unexpected component props")
+	assert.Equal(t, map[string]any{"param1": "asdfasdfasdf"}, tree.Props["PathParams"], "unexpected page path params")
+}
@@ -0,0 +1,149 @@
+package templater
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"text/template/parse"
+)
+
+// componentFuncs are the template functions taking component names, by the indexes of their name arguments.
+var componentFuncs = map[string][]int{
+	"component":       {1},
+	"componentCached": {1},
+	"lazyComponent":   {1},
+	"ifVisible":       {1},
+	"componentSafe":   {1, 2},
+	"skeleton":        {1},
+}
+
+// UnusedComponents statically analyses the layout, page, and component files for uses of components,
+// returning the names of the component files never used, eg to prune dead templates.
+// Components used with names determined at execution, eg {{ component .Name }}, can't be known,
+// so if there are any, the component files otherwise unused are returned as possibly unused instead.
+// Variants of used components are likewise possibly unused. Uses are found via DependencyGraph.
+func (tm *Templater) UnusedComponents() (unused, possiblyUnused []string, err error) {
+	componentDir := path.Join(tm.cfg.Dirs.Base, tm.cfg.Dirs.Components)
+
+	components, err := listTemplateFiles(tm.cfg.FS, componentDir, tm.cfg.FileExt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	graph, err := tm.DependencyGraph()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	used := make(map[string]bool)
+	var dynamic bool
+	for _, deps := range graph {
+		for _, dep := range deps {
+			if dep == DependencyUnknown {
+				dynamic = true
+			} else if c, ok := strings.CutPrefix(dep, path.Clean(tm.cfg.Dirs.Components)+"/"); ok {
+				used[c] = true
+			}
+		}
+	}
+
+	for _, c := range components {
+		switch {
+		case used[c]:
+		case dynamic || isVariantOfUsed(c, used):
+			possiblyUnused = append(possiblyUnused, c)
+		default:
+			unused = append(unused, c)
+		}
+	}
+
+	return unused, possiblyUnused, nil
+}
+
+// walkComponentNames calls fn with each component name argument within the node,
+// ok being false for those not string constants.
+func walkComponentNames(node parse.Node, fn func(name string, ok bool)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkComponentNames(child, fn)
+		}
+	case *parse.ActionNode:
+		walkComponentNames(n.Pipe, fn)
+	case *parse.IfNode:
+		walkBranchComponentNames(&n.BranchNode, fn)
+	case *parse.RangeNode:
+		walkBranchComponentNames(&n.BranchNode, fn)
+	case *parse.WithNode:
+		walkBranchComponentNames(&n.BranchNode, fn)
+	case *parse.TemplateNode:
+		walkComponentNames(n.Pipe, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkComponentNames(cmd, fn)
+		}
+	case *parse.CommandNode:
+		if id, ok := n.Args[0].(*parse.IdentifierNode); ok {
+			for _, i := range componentFuncs[id.Ident] {
+				if i >= len(n.Args) {
+					continue
+				}
+				if s, ok := n.Args[i].(*parse.StringNode); ok {
+					fn(s.Text, true)
+				} else {
+					fn("", false)
+				}
+			}
+		}
+		for _, arg := range n.Args {
+			walkComponentNames(arg, fn)
+		}
+	}
+}
+
+func walkBranchComponentNames(n *parse.BranchNode, fn func(name string, ok bool)) {
+	walkComponentNames(n.Pipe, fn)
+	walkComponentNames(n.List, fn)
+	walkComponentNames(n.ElseList, fn)
+}
+
+// isVariantOfUsed reports whether the component is a variant of a used component, eg card.B of card.
+func isVariantOfUsed(component string, used map[string]bool) bool {
+	i := strings.LastIndex(component, ".")
+	return i > 0 && used[component[:i]]
+}
+
+// listTemplateFiles returns the paths of the template files within dir, relative to it, without the extension.
+func listTemplateFiles(fsys fs.FS, dir, ext string) ([]string, error) {
+	dirFS, err := subFS(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template files: %w", err)
+	}
+
+	var files []string
+
+	err = fs.WalkDir(dirFS, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(p, ext) {
+			files = append(files, strings.TrimSuffix(p, ext))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template files: %w", err)
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
@@ -0,0 +1,60 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_UnusedComponents(t *testing.T) {
+	type (
+		Expected struct {
+			Unused         []string
+			PossiblyUnused []string
+		}
+		Test struct {
+			Name     string
+			Base     string
+			Expected Expected
+		}
+	)
+
+	tests := []Test{
+		{
+			Name: "Given components used with constant names " +
+				"Then the components never used are unused, and variants of used components possibly unused",
+			Base: "test_dir/unused_templates",
+			Expected: Expected{
+				Unused:         []string{"old_banner"},
+				PossiblyUnused: []string{"card.B"},
+			},
+		},
+		{
+			Name: "Given a component used with a dynamic name " +
+				"Then the components not otherwise used are possibly unused",
+			Base: "test_dir/dynamic_templates",
+			Expected: Expected{
+				PossiblyUnused: []string{"chart"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tm := new(Templater).With(Config{
+				Dirs: DirsConfig{
+					Base:       test.Base,
+					Pages:      "pages",
+					Components: "components",
+				},
+			})
+
+			unused, possiblyUnused, err := tm.UnusedComponents()
+			require.NoError(t, err, "unexpected error returned: %+v", err)
+
+			assert.Equal(t, test.Expected.Unused, unused, "unexpected unused components returned")
+			assert.Equal(t, test.Expected.PossiblyUnused, possiblyUnused, "unexpected possibly unused components returned")
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package templater
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// componentSchema is the schema file of a component, eg card.schema.json alongside card.html.tmpl,
+// declaring the props of the component.
+type componentSchema struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+	Required   []string                   `json:"required"`
+}
+
+// readSchema reads the schema file of the page or component of the given name,
+// returning nil if it has none.
+func readSchema(fsys fs.FS, schemaFile, name string) (*componentSchema, error) {
+	b, err := fs.ReadFile(fsys, schemaFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read schema of %s: %w", name, err)
+	}
+
+	var schema componentSchema
+	if err := json.Unmarshal(b, &schema); err != nil {
+		return nil, fmt.Errorf("invalid schema of %s: %w", name, err)
+	}
+
+	return &schema, nil
+}
+
+// ValidateComponent checks that the component of the given name parses, and if it has a schema file,
+// eg card.schema.json alongside card.html.tmpl, that the schema is valid, and each required prop it lists is declared.
+// It's intended for granular feedback during development, without executing the component.
+func (tm *Templater) ValidateComponent(name string) error {
+	ec := tm.newContext()
+
+	if registered := ec.caches.registeredComponent(name); registered != nil {
+		// registered components are parsed when registered
+		return nil
+	}
+
+	componentDir := path.Join(tm.cfg.Dirs.Base, tm.cfg.Dirs.Components)
+
+	match, _, err := ec.findTemplateFile(name, componentDir, map[string]any{})
+	if err != nil {
+		return err
+	}
+
+	t := template.New(name).
+		Funcs(ec.buildFuncMap(name, map[string]any{}))
+	if _, err := parseTemplateFile(t, tm.cfg.FS, path.Join(componentDir, match), path.Base(match)); err != nil {
+		return fmt.Errorf("invalid component %s: %w", name, err)
+	}
+
+	schemaFile := path.Join(componentDir, strings.TrimSuffix(match, tm.cfg.FileExt)+".schema.json")
+
+	schema, err := readSchema(tm.cfg.FS, schemaFile, name)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	for _, prop := range schema.Required {
+		if _, ok := schema.Properties[prop]; !ok {
+			return fmt.Errorf("invalid schema of component %s: required prop %s is not a declared property", name, prop)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates every component file, as ValidateComponent does, returning the errors of all those invalid.
+func (tm *Templater) Validate() error {
+	components, err := listTemplateFiles(tm.cfg.FS, path.Join(tm.cfg.Dirs.Base, tm.cfg.Dirs.Components), tm.cfg.FileExt)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, name := range components {
+		if err := tm.ValidateComponent(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,30 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplater_ValidateComponent(t *testing.T) {
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base:       "test_dir/validate_templates",
+			Pages:      "pages",
+			Components: "components",
+		},
+	})
+
+	assert.NoError(t, tm.ValidateComponent("card"), "expected a valid component")
+	assert.EqualError(t, tm.ValidateComponent("profile"),
+		"invalid schema of component profile: required prop Avatar is not a declared property", "unexpected error returned")
+	assert.ErrorContains(t, tm.ValidateComponent("broken"), "invalid component broken", "expected a parse error")
+
+	var nfErr *ErrNotTemplateFileFound
+	assert.ErrorAs(t, tm.ValidateComponent("no_such"), &nfErr, "expected an ErrNotTemplateFileFound")
+
+	err := tm.Validate()
+	assert.ErrorContains(t, err, "required prop Avatar", "expected the invalid schema to be reported")
+	assert.ErrorContains(t, err, "invalid component broken", "expected the invalid component to be reported")
+	assert.NotContains(t, err.Error(), "component card", "expected the valid component not to be reported")
+}
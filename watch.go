@@ -0,0 +1,117 @@
+package templater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch watches the templates directory for changes via filesystem notifications, eg during development,
+// until the context is done. The path, relative to Dirs.Base, of each template file added, modified, or removed
+// is sent on the returned channel, eg for the app to reload connected browsers, having invalidated its cached parse,
+// as by Invalidate, and called Config.OnTemplateChange, if set. The channel is closed once watching stops.
+// Directories added while watching are watched too, as are Config.OverlayDirs. The channel must be received from for watching to proceed.
+// Only templates read from the operating system's filesystem, the default Config.FS, may be watched.
+// Config.PollInterval is an alternative for filesystems without notifications, eg network filesystems.
+func (tm *Templater) Watch(ctx context.Context) (<-chan string, error) {
+	fsys := tm.cfg.FS
+	roots := []string{tm.cfg.Dirs.Base}
+	if o, ok := fsys.(*overlayFS); ok {
+		fsys = o.fsys
+		roots = append(o.overlays, roots...)
+	}
+	if _, ok := fsys.(osFS); !ok {
+		return nil, errors.New("only templates of the operating system's filesystem may be watched")
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch templates: %w", err)
+	}
+
+	for _, root := range roots {
+		if err := watchDirs(w, root); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+
+	changes := make(chan string)
+	onChange := tm.cfg.OnTemplateChange
+
+	go func() {
+		defer close(changes)
+		defer w.Close()
+
+		for {
+			var event fsnotify.Event
+			select {
+			case <-ctx.Done():
+				return
+			case event = <-w.Events:
+			case <-w.Errors:
+				// eg the event queue overflowed; the changes missed can't be known, so watching continues
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = watchDirs(w, event.Name)
+					continue
+				}
+			}
+			if !event.Has(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename) ||
+				!strings.HasSuffix(event.Name, tm.cfg.FileExt) {
+				continue
+			}
+
+			name, ok := relativeToRoots(roots, event.Name)
+			if !ok {
+				continue
+			}
+
+			tm.templateChanged(name, onChange)
+
+			select {
+			case <-ctx.Done():
+				return
+			case changes <- name:
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// relativeToRoots returns the path of the file relative to the root directory containing it,
+// eg Dirs.Base or one of Config.OverlayDirs.
+func relativeToRoots(roots []string, file string) (string, bool) {
+	for _, root := range roots {
+		if rel, err := filepath.Rel(root, file); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel), true
+		}
+	}
+	return "", false
+}
+
+// watchDirs adds the directory, and every directory within it, to the watcher.
+func watchDirs(w *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := w.Add(p); err != nil {
+			return fmt.Errorf("failed to watch directory %s: %w", p, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,98 @@
+package templater
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_Watch(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "components", "watched.html.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+	require.NoError(t, os.WriteFile(file, []byte("<div>before</div>"), 0o644))
+
+	tm := new(Templater).With(Config{
+		Dirs: DirsConfig{
+			Base: dir,
+		},
+		CacheTemplates: true,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := tm.Watch(ctx)
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+
+	b, err := tm.ExecuteComponent("watched")
+	require.NoError(t, err, "unexpected error returned: %+v", err)
+	assert.Equal(t, "<div>before</div>", string(b), "unexpected bytes returned")
+
+	t.Run("Given a template modified "+
+		"Then the change is sent, and the template parsed again", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("<div>after</div>"), 0o644))
+
+		select {
+		case name := <-changes:
+			assert.Equal(t, "components/watched.html.tmpl", name, "unexpected template change sent")
+		case <-time.After(time.Second):
+			t.Fatal("expected the modified template to be detected")
+		}
+
+		b, err := tm.ExecuteComponent("watched")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+		assert.Equal(t, "<div>after</div>", string(b), "unexpected bytes returned")
+	})
+
+	t.Run("Given a template added to a new directory "+
+		"Then the change is sent", func(t *testing.T) {
+		added := filepath.Join(dir, "components", "nested", "added.html.tmpl")
+		require.NoError(t, os.MkdirAll(filepath.Dir(added), 0o755))
+
+		// let the new directory be watched
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, os.WriteFile(added, []byte("<div>added</div>"), 0o644))
+
+		// writing a file may be notified as several events, eg the previous write
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case name := <-changes:
+				if name == "components/nested/added.html.tmpl" {
+					return
+				}
+			case <-timeout:
+				t.Fatal("expected the added template to be detected")
+			}
+		}
+	})
+
+	t.Run("Given the context canceled "+
+		"Then the channel is closed", func(t *testing.T) {
+		cancel()
+
+		for {
+			select {
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+			case <-time.After(time.Second):
+				t.Fatal("expected the channel to be closed")
+			}
+		}
+	})
+
+	t.Run("Given templates not of the operating system's filesystem "+
+		"Then they can't be watched", func(t *testing.T) {
+		_, err := new(Templater).With(Config{FS: fstest.MapFS{}}).Watch(context.Background())
+		assert.Error(t, err, "expected an error to be returned")
+	})
+}
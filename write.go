@@ -0,0 +1,83 @@
+package templater
+
+import (
+	"fmt"
+	"io"
+)
+
+// ExecutePageTo is ExecutePage, writing the output to w as it's executed, eg to an http.ResponseWriter,
+// rather than buffering it in full. The output of each component is still buffered until it's inserted into the page.
+// If execution fails having written some of the output, the error is returned, and w is left with the partial output,
+// so for an http.ResponseWriter, the response has been committed, and should be abandoned rather than an error page written.
+// Options processing the output as a whole, eg Config.TwoPass, Config.PostProcessors, or Config.AfterRender,
+// require buffering it, so when set, the output is written only once it's complete, as with ExecutePage.
+func (tm *Templater) ExecutePageTo(w io.Writer, name string, kvs ...any) error {
+	if !tm.cfg.streamable() {
+		return writeBuffered(w)(tm.ExecutePage(name, kvs...))
+	}
+
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return err
+	}
+
+	ec := tm.newContext()
+	if ec.cfg.BeforeRender != nil {
+		ec.cfg.BeforeRender(RenderKindPage, name, props)
+	}
+
+	layout, err := ec.parsePage(name, props)
+	if err != nil {
+		return err
+	}
+
+	if err := layout.Execute(w, props); err != nil {
+		return fmt.Errorf("failed to execute html template: %w", err)
+	}
+
+	return nil
+}
+
+// ExecuteComponentTo is ExecuteComponent, writing the output to w as it's executed, as ExecutePageTo does.
+func (tm *Templater) ExecuteComponentTo(w io.Writer, name string, kvs ...any) error {
+	if !tm.cfg.streamable() {
+		return writeBuffered(w)(tm.ExecuteComponent(name, kvs...))
+	}
+
+	props, err := tm.newProps(kvs...)
+	if err != nil {
+		return err
+	}
+
+	ec := tm.newContext()
+	if ec.cfg.BeforeRender != nil {
+		ec.cfg.BeforeRender(RenderKindComponent, name, props)
+	}
+
+	_, err = ec.writeComponent(w, name, props)
+	return err
+}
+
+// streamable reports whether the output of an execution may be written as it's executed,
+// as no option processes it as a whole.
+func (c *Config) streamable() bool {
+	return !c.TwoPass &&
+		len(c.PostProcessors) == 0 &&
+		!c.CheckDuplicateIDs &&
+		!c.AutoNonce &&
+		c.OutputEncoding == "" &&
+		!c.BundleAssets &&
+		!c.CollectState &&
+		c.AfterRender == nil
+}
+
+// writeBuffered returns a func writing the complete output of an execution to w, unless it failed.
+func writeBuffered(w io.Writer) func(b []byte, err error) error {
+	return func(b []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+}
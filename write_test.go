@@ -0,0 +1,65 @@
+package templater
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplater_ExecuteTo(t *testing.T) {
+	newTemplater := func(cfg Config) *Templater {
+		cfg.Dirs = DirsConfig{
+			Base:       "test_dir/test_templates",
+			Pages:      "test_pages",
+			Components: "test_components",
+		}
+		return new(Templater).With(cfg)
+	}
+
+	t.Run("Given a page "+
+		"Then the output written matches ExecutePage", func(t *testing.T) {
+		tm := newTemplater(Config{})
+
+		expected, err := tm.ExecutePage("nested_page")
+		require.NoError(t, err, "unexpected error returned: %+v", err)
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, tm.ExecutePageTo(buf, "nested_page"))
+		assert.Equal(t, string(expected), buf.String(), "unexpected output")
+	})
+
+	t.Run("Given a component "+
+		"Then the output written matches ExecuteComponent", func(t *testing.T) {
+		tm := newTemplater(Config{})
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, tm.ExecuteComponentTo(buf, "badge", "Label", "new"))
+		assert.Equal(t, "<div class=\"badge\">new</div>\n", buf.String(), "unexpected output")
+	})
+
+	t.Run("Given a page failing partway through "+
+		"Then the output preceding the failure is written", func(t *testing.T) {
+		tm := newTemplater(Config{})
+		require.NoError(t, tm.Override(OverrideKindLayout, "", `<main>{{ block "body" . }}{{ end }}</main>`))
+		require.NoError(t, tm.Override(RenderKindPage, "failing", `<p>before</p>{{ component "broken" }}<p>after</p>`))
+
+		buf := new(bytes.Buffer)
+		err := tm.ExecutePageTo(buf, "failing")
+		assert.ErrorContains(t, err, "broken", "unexpected error returned")
+		assert.Equal(t, "<main><p>before</p>", buf.String(), "unexpected partial output")
+	})
+
+	t.Run("Given an option processing the output as a whole "+
+		"Then nothing is written on failure", func(t *testing.T) {
+		tm := newTemplater(Config{CheckDuplicateIDs: true})
+		require.NoError(t, tm.Override(OverrideKindLayout, "", `<main>{{ block "body" . }}{{ end }}</main>`))
+		require.NoError(t, tm.Override(RenderKindPage, "failing", `<p>before</p>{{ component "broken" }}<p>after</p>`))
+
+		buf := new(bytes.Buffer)
+		err := tm.ExecutePageTo(buf, "failing")
+		assert.ErrorContains(t, err, "broken", "unexpected error returned")
+		assert.Zero(t, buf.Len(), "expected no output")
+	})
+}